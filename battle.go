@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/snyderg13/pokedex/internal/battle"
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// a battle that goes on this long ends with the wild pokemon fleeing
+const maxBattleTurns = 100
+
+// pokemon remember the last four moves they learned
+const maxBattleMoves = 4
+
+// known by pokemon without any level-up moves in PokeAPI
+var tackle = battle.Move{Name: "tackle", Type: "normal", Class: battle.Physical, Power: 40, Accuracy: 100}
+
+// returns the moves a pokemon knows at its level, which like in the
+// games are the last ones it learned by leveling up
+func battleMoves(ctx context.Context, cfg *cmdConfig, species pokeapi.PokemonStats, level int) ([]battle.Move, error) {
+	var names []string
+	for _, l := range species.Learnset(species.LatestVersionGroup(), pokeapi.LearnLevelUp) {
+		if l.Level <= level && !slices.Contains(names, l.Name) {
+			names = append(names, l.Name)
+		}
+	}
+	if len(names) == 0 {
+		return []battle.Move{tackle}, nil
+	}
+	names = names[max(len(names)-maxBattleMoves, 0):]
+
+	moves, err := cfg.client.GetMoves(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	known := make([]battle.Move, len(moves))
+	for i, m := range moves {
+		known[i] = battle.Move{
+			Name:     m.Name,
+			Type:     m.Type.Name,
+			Class:    m.DamageClass.Name,
+			Power:    m.Power,
+			Accuracy: m.Accuracy,
+			Priority: m.Priority,
+		}
+	}
+	return known, nil
+}
+
+// builds the battle engine's view of a pokemon
+func newBattler(p *trainer.Pokemon, species pokeapi.PokemonStats, moves []battle.Move) *battle.Battler {
+	current := p.CurrentStats(species.Summary())
+	stats := battle.Stats{
+		HP:             current["hp"],
+		Attack:         current["attack"],
+		Defense:        current["defense"],
+		SpecialAttack:  current["special-attack"],
+		SpecialDefense: current["special-defense"],
+		Speed:          current["speed"],
+	}
+
+	var types []string
+	for _, t := range species.Types {
+		types = append(types, t.Type.Name)
+	}
+
+	return &battle.Battler{
+		Name:  p.DisplayName(),
+		Level: p.Level,
+		Types: types,
+		Stats: stats,
+		HP:    stats.HP,
+		Moves: moves,
+	}
+}
+
+func commandBattle(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <pokemon_name> [level]")
+	}
+	party := cfg.trainer.PartyPokemon()
+	if len(party) == 0 {
+		return errEmptyParty
+	}
+	lead := party[0]
+
+	results, err := cfg.client.GetPokemon(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("%w: %s", errNoSuchPokemon, args[0])
+	} else if err != nil {
+		return err
+	}
+
+	wild := newWildPokemon(cfg, results.Name)
+	wild.Species = results.Name
+	if len(args) > 1 {
+		level, err := strconv.Atoi(args[1])
+		if err != nil || level < 1 || level > trainer.MaxLevel {
+			return fmt.Errorf("level must be a number from 1 to %d", trainer.MaxLevel)
+		}
+		wild.Level = level
+	}
+	cfg.trainer.See(results.Name, results.ID)
+
+	chart, err := typeChart(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	// the saved species data has no moves, so the lead is fetched again,
+	// most likely from the cache
+	leadSpecies, err := cfg.client.GetPokemon(ctx, lead.Species)
+	if err != nil {
+		return err
+	}
+	leadMoves, err := battleMoves(ctx, cfg, leadSpecies, lead.Level)
+	if err != nil {
+		return err
+	}
+	wildMoves, err := battleMoves(ctx, cfg, results, wild.Level)
+	if err != nil {
+		return err
+	}
+
+	player := newBattler(lead, leadSpecies, leadMoves)
+	foe := newBattler(wild, results, wildMoves)
+	foe.Name = "the wild " + foe.Name
+	b := battle.New(player, foe, cfg.rng, battle.WithChart(chart))
+
+	fmt.Printf("A wild %s (lv %d) appeared!\n", results.Name, wild.Level)
+	fmt.Printf("Go, %s!\n", player.Name)
+	for _, ev := range b.Run(maxBattleTurns) {
+		printBattleEvent(b, ev)
+	}
+
+	switch winner, over := b.Winner(); {
+	case !over:
+		fmt.Printf("%s fled!\n", foe.Name)
+	case winner == battle.Player:
+		if err := gainExp(ctx, cfg, lead, trainer.ExpYield(results.BaseExperience, wild.Level)); err != nil {
+			fmt.Println("warning: could not award exp:", err)
+		}
+	default:
+		fmt.Printf("You rush %s to the pokemon center\n", player.Name)
+	}
+
+	autosave(cfg)
+	return nil
+}
+
+// prints what happened in one battle event the way the games do
+func printBattleEvent(b *battle.Battle, ev battle.Event) {
+	attacker, defender := b.Sides[ev.Attacker], b.Sides[ev.Attacker.Other()]
+	fmt.Printf("%s used %s!\n", attacker.Name, ev.Move)
+
+	switch {
+	case ev.Missed:
+		fmt.Println("  but it missed!")
+		return
+	case ev.Effectiveness == 0:
+		fmt.Printf("  it doesn't affect %s...\n", defender.Name)
+		return
+	case ev.Damage == 0:
+		fmt.Println("  but nothing happened")
+		return
+	}
+
+	if ev.Critical {
+		fmt.Println("  a critical hit!")
+	}
+	if ev.Effectiveness > 1 {
+		fmt.Println("  it's super effective!")
+	} else if ev.Effectiveness < 1 {
+		fmt.Println("  it's not very effective...")
+	}
+	fmt.Printf("  %s took %d damage (%d/%d HP)\n", defender.Name, ev.Damage, ev.HPLeft, defender.Stats.HP)
+	if ev.Fainted {
+		fmt.Printf("%s fainted!\n", defender.Name)
+	}
+}
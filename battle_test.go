@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func TestCommandBattle(t *testing.T) {
+	const pikachu = `{"id": 25, "name": "pikachu", "base_experience": 112, "species": {"name": "pikachu"}, "stats": [{"base_stat": 35, "stat": {"name": "hp"}}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/pikachu/":
+			fmt.Fprint(w, pikachu)
+		case "/growth-rate/medium/":
+			fmt.Fprint(w, mediumGrowthRate)
+		case "/type/":
+			fmt.Fprint(w, `{"count": 1, "results": [{"name": "electric"}]}`)
+		case "/type/electric/":
+			fmt.Fprint(w, `{"name": "electric", "damage_relations": {"half_damage_to": [{"name": "electric"}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &cmdConfig{
+		client:  newTestClient(t, pokeapi.WithBaseURL(srv.URL)),
+		trainer: trainer.New("ash"),
+		rng:     rand.New(rand.NewPCG(1, 2)),
+	}
+
+	if err := commandBattle(context.Background(), cfg, "pikachu"); err != errEmptyParty {
+		t.Errorf("expected errEmptyParty, got %v", err)
+	}
+
+	var species pokeapi.PokemonStats
+	if err := json.Unmarshal([]byte(pikachu), &species); err != nil {
+		t.Fatal(err)
+	}
+	lead := &trainer.Pokemon{Level: 8, Exp: 512, GrowthRate: "medium"}
+	cfg.trainer.RecordThrow(species, lead)
+
+	if err := commandBattle(context.Background(), cfg, "pikachuu"); err == nil {
+		t.Errorf("expected an error for an unknown pokemon")
+	}
+
+	// the level 8 lead can't lose against a level 2 pikachu
+	if err := commandBattle(context.Background(), cfg, "pikachu", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lead.Exp != 512+112*2/7 || lead.Level != 8 {
+		t.Errorf("expected the lead to gain 32 exp, got %d at level %d", lead.Exp, lead.Level)
+	}
+}
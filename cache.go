@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/pokecache"
+)
+
+// diskCacheMaxBytes caps the disk tier, room for thousands of gzipped
+// responses
+const diskCacheMaxBytes = 256 << 20
+
+// builds the memory cache and, when dir is set, a disk tier behind it;
+// tiered is nil if responses are only cached in memory
+func newCache(dir string) (memory *pokecache.Cache, tiered *pokecache.TieredCache) {
+	memory = pokeapi.NewMemoryCache(pokeapi.DefaultTTLPolicy)
+	if dir == "" {
+		return memory, nil
+	}
+	disk, err := pokecache.NewDiskCache(dir, pokeapi.DefaultTTLPolicy.Default,
+		pokecache.WithGzip(),
+		pokecache.WithDiskMaxBytes(diskCacheMaxBytes),
+	)
+	if err != nil {
+		fmt.Println("warning: responses won't be cached on disk:", err)
+		return memory, nil
+	}
+	return memory, pokecache.NewTieredCache(memory, disk)
+}
+
+// the cache handed to the client for what newCache built
+func clientCache(memory *pokecache.Cache, tiered *pokecache.TieredCache) pokeapi.Cache {
+	if tiered == nil {
+		return memory
+	}
+	return tiered
+}
+
+func commandCache(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		args = []string{"stats"}
+	}
+
+	switch args[0] {
+	case "stats":
+		if cfg.tiered != nil {
+			stats := cfg.tiered.Stats()
+			fmt.Printf("Overall: %d hits, %d misses (%.1f%% hit rate)\n", stats.Hits, stats.Misses, 100*stats.HitRate())
+			fmt.Println("Each tier below counts its own lookups, so a disk hit is also a memory miss.")
+		}
+		printCacheStats("Memory", cfg.cache.Stats())
+		if cfg.tiered != nil {
+			printCacheStats("Disk ("+cfg.tiered.Disk.Dir()+")", cfg.tiered.Disk.Stats())
+		}
+	case "keys":
+		printCacheKeys("Memory", cfg.cache.Keys())
+		if cfg.tiered != nil {
+			printCacheKeys("Disk", cfg.tiered.Disk.Keys())
+		}
+	case "clear":
+		cfg.cache.Clear()
+		if cfg.tiered != nil {
+			cfg.tiered.Disk.Clear()
+		}
+		fmt.Println("Cleared the cache")
+	case "evict":
+		if len(args) < 2 {
+			return fmt.Errorf("not enough args, expected cache evict <url>")
+		}
+		url := cacheKey(cfg, args[1])
+		removed := cfg.cache.Remove(url)
+		if cfg.tiered != nil && cfg.tiered.Disk.Remove(url) {
+			removed = true
+		}
+		if !removed {
+			return fmt.Errorf("%s is not cached", url)
+		}
+		fmt.Println("Evicted", url)
+	default:
+		return fmt.Errorf("unknown cache command %q, expected stats|keys|clear|evict <url>", args[0])
+	}
+	return nil
+}
+
+// turns a url relative to the API, like pokemon/pikachu, into the key it
+// is cached under
+func cacheKey(cfg *cmdConfig, url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	url = cfg.client.BaseURL() + strings.TrimPrefix(url, "/")
+	if !strings.HasSuffix(url, "/") && !strings.Contains(url, "?") {
+		url += "/"
+	}
+	return url
+}
+
+func printCacheStats(tier string, stats pokecache.Stats) {
+	fmt.Printf("%s:\n", tier)
+	fmt.Printf("  Entries: %d (%s)\n", stats.Entries, formatBytes(stats.Bytes))
+	fmt.Printf("  Hits: %d, misses: %d (%.1f%% hit rate)\n", stats.Hits, stats.Misses, 100*stats.HitRate())
+	fmt.Printf("  Evictions: %d, expirations: %d\n", stats.Evictions, stats.Expirations)
+}
+
+func printCacheKeys(tier string, keys []string) {
+	fmt.Printf("%s (%d):\n", tier, len(keys))
+	for _, key := range keys {
+		fmt.Println("  -", key)
+	}
+}
+
+// formats a size in bytes, e.g. 1.5 KiB
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, prefix := float64(n)/unit, 0
+	for size >= unit && prefix < 3 {
+		size /= unit
+		prefix++
+	}
+	return fmt.Sprintf("%.1f %ciB", size, "KMGT"[prefix])
+}
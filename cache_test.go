@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+func TestCacheKey(t *testing.T) {
+	cfg := &cmdConfig{client: newTestClient(t, pokeapi.WithBaseURL("http://localhost:8000/api/v2"))}
+
+	cases := []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu/", expected: "https://pokeapi.co/api/v2/pokemon/pikachu/"},
+		{url: "pokemon/pikachu", expected: "http://localhost:8000/api/v2/pokemon/pikachu/"},
+		{url: "/move/tackle/", expected: "http://localhost:8000/api/v2/move/tackle/"},
+		{url: "location-area/?offset=20&limit=20", expected: "http://localhost:8000/api/v2/location-area/?offset=20&limit=20"},
+	}
+
+	for _, c := range cases {
+		if actual := cacheKey(cfg, c.url); actual != c.expected {
+			t.Errorf("cacheKey(%q) = %q, expected %q", c.url, actual, c.expected)
+		}
+	}
+}
+
+func TestCommandCache(t *testing.T) {
+	memory, tiered := newCache(t.TempDir())
+	defer memory.Close()
+	if tiered == nil {
+		t.Fatal("expected a disk tier")
+	}
+	disk := tiered.Disk
+	cfg := &cmdConfig{
+		client: newTestClient(t, pokeapi.WithCache(clientCache(memory, tiered))),
+		cache:  memory,
+		tiered: tiered,
+	}
+	ctx := context.Background()
+
+	const url = pokeapi.DefaultBaseURL + "pokemon/pikachu/"
+	memory.Add(url, []byte("{}"))
+	disk.Add(url, []byte("{}"))
+	memory.Add(pokeapi.DefaultBaseURL+"pokemon/eevee/", []byte("{}"))
+
+	for _, args := range [][]string{nil, {"stats"}, {"keys"}} {
+		if err := commandCache(ctx, cfg, args...); err != nil {
+			t.Errorf("%v: unexpected error: %v", args, err)
+		}
+	}
+
+	if err := commandCache(ctx, cfg, "evict", "pokemon/pikachu"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, ok := memory.Get(url); ok {
+		t.Errorf("expected evict to remove the entry from memory")
+	}
+	if _, ok := disk.Get(url); ok {
+		t.Errorf("expected evict to remove the entry from disk")
+	}
+	if err := commandCache(ctx, cfg, "evict", url); err == nil {
+		t.Errorf("expected an error evicting a url that isn't cached")
+	}
+
+	if err := commandCache(ctx, cfg, "clear"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if entries := memory.Stats().Entries; entries != 0 {
+		t.Errorf("expected clear to empty the memory cache, %d entries left", entries)
+	}
+
+	for _, args := range [][]string{{"evict"}, {"flush"}} {
+		if err := commandCache(ctx, cfg, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int]string{
+		0:        "0 B",
+		1023:     "1023 B",
+		1536:     "1.5 KiB",
+		64 << 20: "64.0 MiB",
+		3 << 30:  "3.0 GiB",
+	}
+	for n, expected := range cases {
+		if actual := formatBytes(n); actual != expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", n, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// randSource is the subset of *rand.Rand the game needs, so tests
+// can swap in a source with known results
+type randSource interface {
+	IntN(n int) int
+}
+
+type pokeBall struct {
+	name     string
+	modifier float64
+}
+
+// ball modifiers from the generation III/IV games
+var pokeBalls = []pokeBall{
+	{name: "pokeball", modifier: 1},
+	{name: "greatball", modifier: 1.5},
+	{name: "ultraball", modifier: 2},
+	{name: "masterball", modifier: 255},
+}
+
+// status modifiers; there are no battles yet so wild pokemon
+// are always statusNone for now
+const (
+	statusNone     = 1.0
+	statusPoisoned = 1.5
+	statusAsleep   = 2.0
+)
+
+// catchParams is everything the catch formula looks at
+type catchParams struct {
+	captureRate int
+	maxHP       int
+	currentHP   int
+	ball        float64
+	status      float64
+}
+
+// attemptCatch runs the generation III/IV catch formula and returns how
+// many times the ball shook (0-3) and whether the pokemon was caught.
+//
+// The "modified catch rate" a is
+//
+//	((3*maxHP - 2*currentHP) * captureRate * ball) / (3*maxHP) * status
+//
+// and if it is 255 or more the catch is guaranteed. Otherwise the ball
+// does up to four shake checks, each passing when a random number in
+// [0, 65535] is below b = 1048560 / sqrt(sqrt(16711680 / a)). The first
+// three checks are the shakes the player sees, all four passing is a
+// catch.
+func attemptCatch(rng randSource, p catchParams) (shakes int, caught bool) {
+	maxHP := max(p.maxHP, 1)
+	currentHP := min(max(p.currentHP, 1), maxHP)
+
+	a := math.Floor(float64(3*maxHP-2*currentHP) * float64(p.captureRate) * p.ball / float64(3*maxHP))
+	a = math.Floor(a * p.status)
+	if a >= 255 {
+		return 3, true
+	}
+	a = max(a, 1)
+
+	b := int(math.Floor(1048560 / math.Sqrt(math.Sqrt(16711680/a))))
+	for check := 0; check < 4; check++ {
+		if rng.IntN(65536) >= b {
+			return shakes, false
+		}
+		if check < 3 {
+			shakes++
+		}
+	}
+	return shakes, true
+}
+
+// looks up a ball by name, accepting both "great" and "greatball"
+func findBall(name string) (pokeBall, bool) {
+	name = strings.TrimSuffix(strings.ReplaceAll(name, "-", ""), "ball") + "ball"
+	idx := slices.IndexFunc(pokeBalls, func(b pokeBall) bool { return b.name == name })
+	if idx < 0 {
+		return pokeBall{}, false
+	}
+	return pokeBalls[idx], true
+}
+
+// looks up the base value of a stat such as "hp"
+func baseStat(stats pokeapi.PokemonStats, name string) int {
+	for _, s := range stats.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
+// levelRange returns the levels name can be encountered at in area
+func levelRange(area *pokeapi.LocationDetails, name string) (minLevel, maxLevel int, ok bool) {
+	if area == nil {
+		return 0, 0, false
+	}
+	for _, enc := range area.PokemonList {
+		if enc.Pokemon.Name != name {
+			continue
+		}
+		for _, version := range enc.VersionDetails {
+			for _, detail := range version.EncounterDetails {
+				if !ok || detail.MinLevel < minLevel {
+					minLevel = detail.MinLevel
+				}
+				if !ok || detail.MaxLevel > maxLevel {
+					maxLevel = detail.MaxLevel
+				}
+				ok = true
+			}
+		}
+	}
+	return minLevel, maxLevel, ok
+}
+
+// rolls the level and IVs of a freshly caught pokemon; pokemon
+// found in the explored area get a level from its encounter table
+func newWildPokemon(cfg *cmdConfig, name string) *trainer.Pokemon {
+	p := &trainer.Pokemon{
+		CaughtAt: time.Now(),
+		Level:    trainer.DefaultLevel,
+		IVs:      make(map[string]int, len(trainer.StatNames)),
+	}
+
+	if minLevel, maxLevel, ok := levelRange(cfg.area, name); ok {
+		p.Location = cfg.area.Name
+		p.Level = max(minLevel+cfg.rng.IntN(maxLevel-minLevel+1), 1)
+	}
+	for _, stat := range trainer.StatNames {
+		p.IVs[stat] = cfg.rng.IntN(trainer.MaxIV + 1)
+	}
+
+	return p
+}
+
+func commandCatch(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no pokemon name provided")
+	}
+	name := args[0]
+
+	ball := pokeBalls[0]
+	if len(args) > 1 {
+		var ok bool
+		if ball, ok = findBall(args[1]); !ok {
+			return fmt.Errorf("unknown ball %q", args[1])
+		}
+	}
+
+	results, err := cfg.client.GetPokemon(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("%w: %s", errNoSuchPokemon, name)
+	} else if err != nil {
+		return err
+	}
+
+	species, err := cfg.client.GetPokemonSpecies(ctx, results.Species.Name)
+	if err != nil {
+		return err
+	}
+	rate, err := cfg.client.GetGrowthRate(ctx, species.GrowthRate.Name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Throwing a %s at %s...\n", ball.name, name)
+
+	// wild pokemon are met at full health until battles exist
+	hp := baseStat(results, "hp")
+	shakes, caught := attemptCatch(cfg.rng, catchParams{
+		captureRate: species.CaptureRate,
+		maxHP:       hp,
+		currentHP:   hp,
+		ball:        ball.modifier,
+		status:      statusNone,
+	})
+
+	for i := 0; i < shakes; i++ {
+		fmt.Println("...the ball shakes...")
+	}
+
+	if caught {
+		// the party lead earns exp for the catch, unless
+		// the party was empty and the new pokemon leads it
+		lead := cfg.trainer.PartyPokemon()
+
+		p := newWildPokemon(cfg, name)
+		p.GrowthRate = rate.Name
+		p.Exp = rate.ExperienceFor(p.Level)
+		p.Friendship = species.BaseHappiness
+		place := cfg.trainer.RecordThrow(results, p)
+		fmt.Printf("%s was caught! (#%d)\n", name, p.ID)
+		if place.InParty() {
+			fmt.Printf("%s joined your party in slot %d\n", name, place.Slot)
+		} else {
+			fmt.Printf("Your party is full, %s was sent to %s\n", name, place)
+		}
+
+		if len(lead) > 0 {
+			if err := gainExp(ctx, cfg, lead[0], trainer.ExpYield(results.BaseExperience, p.Level)); err != nil {
+				fmt.Println("warning: could not award exp:", err)
+			}
+		}
+	} else {
+		cfg.trainer.RecordThrow(results, nil)
+		fmt.Println(name, "escaped!")
+	}
+	autosave(cfg)
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// fixedRand hands out the given values in order and fails
+// the test if more are asked for
+type fixedRand struct {
+	t      *testing.T
+	values []int
+}
+
+func (r *fixedRand) IntN(n int) int {
+	if len(r.values) == 0 {
+		r.t.Fatalf("fixedRand ran out of values")
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v % n
+}
+
+func TestAttemptCatch(t *testing.T) {
+	const pass, fail = 0, 65535
+	cases := []struct {
+		name     string
+		params   catchParams
+		rolls    []int
+		shakes   int
+		expected bool
+	}{
+		{
+			name:     "master ball never fails",
+			params:   catchParams{captureRate: 3, maxHP: 100, currentHP: 100, ball: 255, status: statusNone},
+			shakes:   3,
+			expected: true,
+		},
+		{
+			name:     "weak and asleep is guaranteed",
+			params:   catchParams{captureRate: 255, maxHP: 100, currentHP: 1, ball: 1, status: statusAsleep},
+			shakes:   3,
+			expected: true,
+		},
+		{
+			name:     "all four checks pass",
+			params:   catchParams{captureRate: 45, maxHP: 45, currentHP: 45, ball: 1, status: statusNone},
+			rolls:    []int{pass, pass, pass, pass},
+			shakes:   3,
+			expected: true,
+		},
+		{
+			name:     "breaks out straight away",
+			params:   catchParams{captureRate: 45, maxHP: 45, currentHP: 45, ball: 1, status: statusNone},
+			rolls:    []int{fail},
+			shakes:   0,
+			expected: false,
+		},
+		{
+			name:     "breaks out after two shakes",
+			params:   catchParams{captureRate: 45, maxHP: 45, currentHP: 45, ball: 1, status: statusNone},
+			rolls:    []int{pass, pass, fail},
+			shakes:   2,
+			expected: false,
+		},
+		{
+			name:     "breaks out on the last check",
+			params:   catchParams{captureRate: 45, maxHP: 45, currentHP: 45, ball: 1.5, status: statusNone},
+			rolls:    []int{pass, pass, pass, fail},
+			shakes:   3,
+			expected: false,
+		},
+		{
+			// a = 15 so b = 32274
+			name:     "roll just under the threshold passes",
+			params:   catchParams{captureRate: 45, maxHP: 45, currentHP: 45, ball: 1, status: statusNone},
+			rolls:    []int{32273, 32273, 32273, 32274},
+			shakes:   3,
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rng := &fixedRand{t: t, values: c.rolls}
+			shakes, caught := attemptCatch(rng, c.params)
+			if shakes != c.shakes || caught != c.expected {
+				t.Errorf("FAIL: got %d shakes, caught=%v; expected %d shakes, caught=%v", shakes, caught, c.shakes, c.expected)
+			}
+			if len(rng.values) != 0 {
+				t.Errorf("FAIL: %d rolls left unused", len(rng.values))
+			}
+		})
+	}
+}
+
+func TestFindBall(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{input: "pokeball", expected: "pokeball", ok: true},
+		{input: "great", expected: "greatball", ok: true},
+		{input: "ultra-ball", expected: "ultraball", ok: true},
+		{input: "masterball", expected: "masterball", ok: true},
+		{input: "rock", ok: false},
+	}
+
+	for _, c := range cases {
+		ball, ok := findBall(c.input)
+		if ok != c.ok || ball.name != c.expected {
+			t.Errorf("FAIL: findBall(%q) = %q, %v", c.input, ball.name, ok)
+		}
+	}
+}
+
+// the start of the medium (n^3) growth rate
+const mediumGrowthRate = `{"name": "medium", "levels": [
+	{"level": 1, "experience": 0},
+	{"level": 2, "experience": 8},
+	{"level": 3, "experience": 27},
+	{"level": 4, "experience": 64},
+	{"level": 5, "experience": 125},
+	{"level": 6, "experience": 216},
+	{"level": 7, "experience": 343},
+	{"level": 8, "experience": 512},
+	{"level": 9, "experience": 729},
+	{"level": 10, "experience": 1000}
+]}`
+
+func TestCommandCatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/pikachu/":
+			fmt.Fprint(w, `{"id": 25, "name": "pikachu", "base_experience": 112, "species": {"name": "pikachu"}, "stats": [{"base_stat": 35, "stat": {"name": "hp"}}]}`)
+		case "/pokemon-species/pikachu/":
+			fmt.Fprint(w, `{"id": 25, "name": "pikachu", "capture_rate": 190, "growth_rate": {"name": "medium"}}`)
+		case "/growth-rate/medium/":
+			fmt.Fprint(w, mediumGrowthRate)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &cmdConfig{
+		client:  newTestClient(t, pokeapi.WithBaseURL(srv.URL)),
+		trainer: trainer.New("ash"),
+		rng:     &fixedRand{t: t, values: []int{0, 0, 0, 65535}},
+	}
+
+	if err := commandCatch(context.Background(), cfg, "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.trainer.Species["pikachu"]; ok {
+		t.Errorf("expected pikachu to escape")
+	}
+
+	// four shake checks then six IV rolls
+	cfg.rng = &fixedRand{t: t, values: []int{0, 0, 0, 0, 31, 30, 29, 28, 27, 26}}
+	if err := commandCatch(context.Background(), cfg, "pikachu", "great"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.trainer.Species["pikachu"]; !ok {
+		t.Errorf("expected pikachu to be caught")
+	}
+	p, err := cfg.trainer.Find(1)
+	if err != nil {
+		t.Fatalf("expected pikachu to be #1: %v", err)
+	}
+	if p.Species != "pikachu" || p.Level != trainer.DefaultLevel || p.IVs["hp"] != 31 || p.IVs["speed"] != 26 {
+		t.Errorf("unexpected caught pokemon: %+v", p)
+	}
+	if p.GrowthRate != "medium" || p.Exp != 125 {
+		t.Errorf("expected a level 5 medium growth pokemon to have 125 exp, got %+v", p)
+	}
+
+	// the second catch gives the first pikachu 112 * 5 / 7 = 80 exp
+	cfg.rng = &fixedRand{t: t, values: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}
+	if err := commandCatch(context.Background(), cfg, "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Exp != 205 || p.Level != 5 {
+		t.Errorf("expected the lead to have 205 exp at level 5, got %d at %d", p.Exp, p.Level)
+	}
+
+	if cfg.trainer.Stats != (trainer.Stats{BallsThrown: 3, Caught: 2, Escaped: 1}) {
+		t.Errorf("unexpected trainer stats: %+v", cfg.trainer.Stats)
+	}
+
+	if err := commandCatch(context.Background(), cfg, "pikachuu"); err == nil || friendlyError(err) != "no such pokemon: pikachuu" {
+		t.Errorf("expected no such pokemon, got %v", err)
+	}
+}
+
+func TestLevelRange(t *testing.T) {
+	var area pokeapi.LocationDetails
+	err := json.Unmarshal([]byte(`{"name": "viridian-forest-area", "pokemon_encounters": [
+		{"pokemon": {"name": "caterpie"}, "version_details": [
+			{"encounter_details": [{"min_level": 3, "max_level": 5}, {"min_level": 4, "max_level": 6}]},
+			{"encounter_details": [{"min_level": 2, "max_level": 3}]}
+		]},
+		{"pokemon": {"name": "pikachu"}, "version_details": [
+			{"encounter_details": [{"min_level": 3, "max_level": 5}]}
+		]}
+	]}`), &area)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		area     *pokeapi.LocationDetails
+		name     string
+		min, max int
+		ok       bool
+	}{
+		{area: &area, name: "caterpie", min: 2, max: 6, ok: true},
+		{area: &area, name: "pikachu", min: 3, max: 5, ok: true},
+		{area: &area, name: "mew", ok: false},
+		{area: nil, name: "pikachu", ok: false},
+	}
+
+	for _, c := range cases {
+		minLevel, maxLevel, ok := levelRange(c.area, c.name)
+		if minLevel != c.min || maxLevel != c.max || ok != c.ok {
+			t.Errorf("FAIL: levelRange(%s) = %d, %d, %v", c.name, minLevel, maxLevel, ok)
+		}
+	}
+}
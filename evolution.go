@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// fetches the evolution chain a species belongs to
+func evolutionChainOf(ctx context.Context, cfg *cmdConfig, species string) (pokeapi.EvolutionChain, error) {
+	data, err := cfg.client.GetPokemonSpecies(ctx, species)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.EvolutionChain{}, fmt.Errorf("%w: %s", errNoSuchPokemon, species)
+	} else if err != nil {
+		return pokeapi.EvolutionChain{}, err
+	}
+
+	id := data.EvolutionChainID()
+	if id == 0 {
+		// a species without a chain is a chain of its own
+		chain := pokeapi.EvolutionChain{}
+		chain.Chain.Species.Name = data.Name
+		return chain, nil
+	}
+	return cfg.client.GetEvolutionChain(ctx, id)
+}
+
+// evolves p if trig meets the conditions of one of its evolutions
+// and reports whether it did
+func tryEvolve(ctx context.Context, cfg *cmdConfig, p *trainer.Pokemon, trig trainer.Trigger) (bool, error) {
+	species := speciesOf(cfg, p)
+	chain, err := evolutionChainOf(ctx, cfg, species)
+	if err != nil {
+		return false, err
+	}
+
+	into := p.EvolvesInto(chain.Chain.Find(species), trig)
+	if into == "" {
+		return false, nil
+	}
+	// the chain names species, and a species' default pokemon can have
+	// another name, e.g. wormadam is wormadam-plant
+	intoSpecies, err := cfg.client.GetPokemonSpecies(ctx, into)
+	if err != nil {
+		return false, err
+	}
+	stats, err := cfg.client.GetPokemon(ctx, intoSpecies.DefaultPokemon())
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("What? %s is evolving!\n", p.DisplayName())
+	before := p.DisplayName()
+	cfg.trainer.Evolve(p, stats)
+	fmt.Printf("Congratulations! %s evolved into %s!\n", before, stats.Name)
+	return true, nil
+}
+
+func commandEvolve(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <#id> [item|trade [item]]")
+	}
+	p, err := findPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	// without an item or trade this checks level and
+	// friendship evolutions the pokemon already qualifies for
+	trig := trainer.Trigger{Kind: trainer.TriggerLevelUp, Time: time.Now()}
+	switch {
+	case len(args) > 1 && args[1] == "trade":
+		trig.Kind = trainer.TriggerTrade
+		if len(args) > 2 {
+			trig.Item = args[2]
+		}
+	case len(args) > 1:
+		trig.Kind = trainer.TriggerUseItem
+		trig.Item = args[1]
+	}
+
+	evolved, err := tryEvolve(ctx, cfg, p, trig)
+	if err != nil {
+		return err
+	}
+	if !evolved {
+		fmt.Printf("%s can't evolve that way right now\n", p.DisplayName())
+		return nil
+	}
+	autosave(cfg)
+	return nil
+}
+
+func commandEvolutions(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <pokemon_name|#id>")
+	}
+	species := args[0]
+	if strings.HasPrefix(species, "#") {
+		p, err := findPokemon(cfg, species)
+		if err != nil {
+			return err
+		}
+		species = speciesOf(cfg, p)
+	}
+
+	chain, err := evolutionChainOf(ctx, cfg, species)
+	if err != nil {
+		return err
+	}
+	fmt.Println(chain.Chain.Species.Name)
+	printEvolutions(chain.Chain.EvolvesTo, "")
+	return nil
+}
+
+// prints the branches of an evolution chain as a tree
+func printEvolutions(links []pokeapi.ChainLink, indent string) {
+	for i, link := range links {
+		branch, next := "├── ", "│   "
+		if i == len(links)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s (%s)\n", indent, branch, link.Species.Name, describeEvolution(link.EvolutionDetails))
+		printEvolutions(link.EvolvesTo, indent+next)
+	}
+}
+
+// describes the ways to reach a link of an evolution chain
+func describeEvolution(details []pokeapi.EvolutionDetail) string {
+	var ways []string
+	for _, d := range details {
+		var conds []string
+		switch d.Trigger.Name {
+		case trainer.TriggerLevelUp:
+			if d.MinLevel > 0 {
+				conds = append(conds, fmt.Sprintf("level %d", d.MinLevel))
+			} else {
+				conds = append(conds, "level up")
+			}
+		case trainer.TriggerUseItem:
+			conds = append(conds, "use "+d.Item.Name)
+		case trainer.TriggerTrade:
+			conds = append(conds, "trade")
+		default:
+			conds = append(conds, d.Trigger.Name)
+		}
+		if d.HeldItem.Name != "" {
+			conds = append(conds, "holding "+d.HeldItem.Name)
+		}
+		if d.MinHappiness > 0 {
+			conds = append(conds, fmt.Sprintf("friendship %d", d.MinHappiness))
+		}
+		if d.TimeOfDay != "" {
+			conds = append(conds, "at "+d.TimeOfDay)
+		}
+		if d.Unsupported() {
+			conds = append(conds, "special conditions")
+		}
+		ways = append(ways, strings.Join(conds, ", "))
+	}
+	if len(ways) == 0 {
+		return "unknown"
+	}
+	return strings.Join(ways, " or ")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func TestTryEvolveDefaultVariety(t *testing.T) {
+	const burmy = `{"id": 412, "name": "burmy", "species": {"name": "burmy"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species/burmy/":
+			fmt.Fprint(w, `{"name": "burmy", "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/213/"}}`)
+		case "/evolution-chain/213/":
+			fmt.Fprint(w, `{"id": 213, "chain": {"species": {"name": "burmy"}, "evolves_to": [
+				{"species": {"name": "wormadam"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 20}]}
+			]}}`)
+		// there is no pokemon named wormadam, only its forms
+		case "/pokemon-species/wormadam/":
+			fmt.Fprint(w, `{"name": "wormadam", "varieties": [
+				{"is_default": true, "pokemon": {"name": "wormadam-plant"}},
+				{"is_default": false, "pokemon": {"name": "wormadam-sandy"}}
+			]}`)
+		case "/pokemon/wormadam-plant/":
+			fmt.Fprint(w, `{"id": 413, "name": "wormadam-plant", "species": {"name": "wormadam"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &cmdConfig{
+		client:  newTestClient(t, pokeapi.WithBaseURL(srv.URL)),
+		trainer: trainer.New("ash"),
+	}
+
+	var species pokeapi.PokemonStats
+	if err := json.Unmarshal([]byte(burmy), &species); err != nil {
+		t.Fatal(err)
+	}
+	p := &trainer.Pokemon{Nickname: "cloak", Level: 20}
+	cfg.trainer.RecordThrow(species, p)
+
+	evolved, err := tryEvolve(context.Background(), cfg, p, trainer.Trigger{Kind: trainer.TriggerLevelUp, Time: time.Now()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !evolved || p.Species != "wormadam-plant" || p.Nickname != "cloak" {
+		t.Errorf("expected cloak to evolve into wormadam-plant, got %v %+v", evolved, p)
+	}
+	if got := speciesOf(cfg, p); got != "wormadam" {
+		t.Errorf("expected the species to be wormadam, got %q", got)
+	}
+}
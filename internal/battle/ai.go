@@ -0,0 +1,40 @@
+package battle
+
+// AI picks the move a battler uses on its turn
+type AI interface {
+	// ChooseMove returns an index into self.Moves
+	ChooseMove(self, foe *Battler, chart TypeChart) int
+}
+
+// StrongestMove picks the move with the highest power after STAB and
+// type effectiveness, preferring the first one on ties
+type StrongestMove struct{}
+
+func (StrongestMove) ChooseMove(self, foe *Battler, chart TypeChart) int {
+	best, bestScore := 0, -1.0
+	for i, m := range self.Moves {
+		score := float64(m.Power) * Effectiveness(chart, m.Type, foe.Types)
+		if self.hasType(m.Type) {
+			score *= 1.5
+		}
+		if m.Accuracy > 0 {
+			score *= float64(m.Accuracy) / 100
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// RandomMove picks any of the battler's moves
+type RandomMove struct {
+	RNG RNG
+}
+
+func (r RandomMove) ChooseMove(self, foe *Battler, chart TypeChart) int {
+	if len(self.Moves) == 0 {
+		return 0
+	}
+	return r.RNG.IntN(len(self.Moves))
+}
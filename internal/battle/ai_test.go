@@ -0,0 +1,45 @@
+package battle
+
+import (
+	"testing"
+)
+
+func TestStrongestMove(t *testing.T) {
+	self := newBattler("pikachu", "electric")
+	self.Moves = []Move{
+		{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100},
+		thunderbolt,
+		{Name: "thunder", Type: "electric", Power: 110, Accuracy: 70},
+		{Name: "slam", Type: "normal", Power: 80, Accuracy: 75},
+	}
+
+	cases := []struct {
+		name     string
+		foe      []string
+		expected string
+	}{
+		// thunderbolt: 90 * 1.5 = 135, thunder: 110 * 1.5 * 0.7 = 115.5
+		{name: "stab", foe: []string{"water"}, expected: "thunderbolt"},
+		// electric moves do nothing, slam: 80 * 0.75 = 60
+		{name: "immune", foe: []string{"ground"}, expected: "slam"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			i := StrongestMove{}.ChooseMove(self, newBattler("foe", c.foe...), testChart)
+			if self.Moves[i].Name != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, self.Moves[i].Name)
+			}
+		})
+	}
+}
+
+func TestRandomMove(t *testing.T) {
+	self := newBattler("pikachu", "electric")
+	self.Moves = []Move{thunderbolt, thunderbolt, thunderbolt}
+
+	ai := RandomMove{RNG: &scriptedRNG{t: t, values: []int{2}}}
+	if i := ai.ChooseMove(self, newBattler("foe"), NeutralChart{}); i != 2 {
+		t.Errorf("expected move 2, got %d", i)
+	}
+}
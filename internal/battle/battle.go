@@ -0,0 +1,311 @@
+// Package battle is a turn-based battle engine between two pokemon. It
+// does no I/O: a front end builds the Battlers, picks or delegates moves
+// and renders the Events each turn returns.
+package battle
+
+import (
+	"slices"
+)
+
+// Side identifies one of the two battlers
+type Side int
+
+const (
+	Player Side = iota
+	Foe
+)
+
+// Other returns the opposing side
+func (s Side) Other() Side {
+	return 1 - s
+}
+
+// Damage classes of a move
+const (
+	Physical = "physical"
+	Special  = "special"
+	Status   = "status"
+)
+
+// Move is an attack a battler can use
+type Move struct {
+	Name string
+	Type string
+	// Class is Physical, Special or Status
+	Class string
+	// Power is 0 for moves that deal no damage
+	Power int
+	// Accuracy is a percentage; 0 means the move never misses
+	Accuracy int
+	// Priority moves go before everything of a lower priority,
+	// regardless of speed
+	Priority int
+}
+
+// Struggle is used by a battler that has no moves
+var Struggle = Move{Name: "struggle", Class: Physical, Power: 50}
+
+// Stats are a battler's stats at its current level
+type Stats struct {
+	HP             int
+	Attack         int
+	Defense        int
+	SpecialAttack  int
+	SpecialDefense int
+	Speed          int
+}
+
+// Battler is one pokemon taking part in a battle
+type Battler struct {
+	Name  string
+	Level int
+	Types []string
+	Stats Stats
+	// HP is the battler's current HP, at most Stats.HP
+	HP    int
+	Moves []Move
+}
+
+// Fainted reports whether the battler can no longer fight
+func (b *Battler) Fainted() bool {
+	return b.HP <= 0
+}
+
+// move returns the battler's i-th move, falling back to the first one
+// for an out of range index and to Struggle when it knows none
+func (b *Battler) move(i int) Move {
+	if len(b.Moves) == 0 {
+		return Struggle
+	}
+	if i < 0 || i >= len(b.Moves) {
+		i = 0
+	}
+	return b.Moves[i]
+}
+
+// hasType reports whether the battler is of type t
+func (b *Battler) hasType(t string) bool {
+	return t != "" && slices.Contains(b.Types, t)
+}
+
+// RNG is the source of randomness for a battle. *rand.Rand satisfies it;
+// tests use a scripted one to make turns deterministic.
+type RNG interface {
+	// IntN returns a number in [0, n)
+	IntN(n int) int
+}
+
+// TypeChart tells how effective an attacking type is against a single
+// defending type: 0, 0.5, 1 or 2
+type TypeChart interface {
+	Multiplier(attacking, defending string) float64
+}
+
+// NeutralChart is a TypeChart where every type is normally effective
+type NeutralChart struct{}
+
+func (NeutralChart) Multiplier(attacking, defending string) float64 {
+	return 1
+}
+
+// Effectiveness is the combined multiplier of an attacking type against
+// all of a defender's types
+func Effectiveness(chart TypeChart, attacking string, defending []string) float64 {
+	eff := 1.0
+	for _, t := range defending {
+		eff *= chart.Multiplier(attacking, t)
+	}
+	return eff
+}
+
+// Event is one action taken during a turn
+type Event struct {
+	Turn     int
+	Attacker Side
+	Move     string
+	// Missed is set when the move did not hit
+	Missed bool
+	// Damage is the HP the defender lost
+	Damage        int
+	Critical      bool
+	Effectiveness float64
+	// HPLeft is the defender's HP after the move
+	HPLeft int
+	// Fainted is set when the move made the defender faint
+	Fainted bool
+}
+
+// Battle is a battle in progress. Use New to create one.
+type Battle struct {
+	// Sides holds the battlers, indexed by Side
+	Sides [2]*Battler
+	// Turn is the number of turns played so far
+	Turn int
+
+	rng   RNG
+	chart TypeChart
+	ai    [2]AI
+}
+
+// Option configures a Battle created with New
+type Option func(*Battle)
+
+// WithChart sets the type chart used for effectiveness. The default is
+// NeutralChart.
+func WithChart(chart TypeChart) Option {
+	return func(b *Battle) {
+		b.chart = chart
+	}
+}
+
+// WithAI sets how a side picks its moves in Step. The default is
+// StrongestMove for both sides.
+func WithAI(side Side, ai AI) Option {
+	return func(b *Battle) {
+		b.ai[side] = ai
+	}
+}
+
+// New starts a battle between two battlers
+func New(player, foe *Battler, rng RNG, opts ...Option) *Battle {
+	b := &Battle{
+		Sides: [2]*Battler{player, foe},
+		rng:   rng,
+		chart: NeutralChart{},
+		ai:    [2]AI{StrongestMove{}, StrongestMove{}},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Over reports whether either side has fainted
+func (b *Battle) Over() bool {
+	return b.Sides[Player].Fainted() || b.Sides[Foe].Fainted()
+}
+
+// Winner returns the side still standing once the battle is over
+func (b *Battle) Winner() (Side, bool) {
+	switch {
+	case b.Sides[Foe].Fainted():
+		return Player, true
+	case b.Sides[Player].Fainted():
+		return Foe, true
+	}
+	return 0, false
+}
+
+// PlayTurn plays one turn with the moves each side chose, given as an
+// index into its battler's Moves. Nothing happens once the battle is
+// over.
+func (b *Battle) PlayTurn(moves [2]int) []Event {
+	if b.Over() {
+		return nil
+	}
+	b.Turn++
+
+	chosen := [2]Move{b.Sides[Player].move(moves[Player]), b.Sides[Foe].move(moves[Foe])}
+	var events []Event
+	for _, side := range b.order(chosen) {
+		if b.Over() {
+			break
+		}
+		events = append(events, b.attack(side, chosen[side]))
+	}
+	return events
+}
+
+// Step plays one turn with moves picked by each side's AI
+func (b *Battle) Step() []Event {
+	var moves [2]int
+	for _, side := range []Side{Player, Foe} {
+		moves[side] = b.ai[side].ChooseMove(b.Sides[side], b.Sides[side.Other()], b.chart)
+	}
+	return b.PlayTurn(moves)
+}
+
+// Run plays turns until one side faints or maxTurns were played, and
+// returns everything that happened
+func (b *Battle) Run(maxTurns int) []Event {
+	var events []Event
+	for i := 0; i < maxTurns && !b.Over(); i++ {
+		events = append(events, b.Step()...)
+	}
+	return events
+}
+
+// order returns which side moves first: higher priority, then higher
+// speed, with speed ties broken at random
+func (b *Battle) order(moves [2]Move) [2]Side {
+	first := Player
+	switch p, f := moves[Player].Priority, moves[Foe].Priority; {
+	case p != f:
+		if f > p {
+			first = Foe
+		}
+	default:
+		ps, fs := b.Sides[Player].Stats.Speed, b.Sides[Foe].Stats.Speed
+		if fs > ps || (fs == ps && b.rng.IntN(2) == 1) {
+			first = Foe
+		}
+	}
+	return [2]Side{first, first.Other()}
+}
+
+// attack resolves one move used by side against the other side
+func (b *Battle) attack(side Side, m Move) Event {
+	attacker, defender := b.Sides[side], b.Sides[side.Other()]
+	ev := Event{Turn: b.Turn, Attacker: side, Move: m.Name, Effectiveness: 1, HPLeft: defender.HP}
+
+	if m.Accuracy > 0 && b.rng.IntN(100) >= m.Accuracy {
+		ev.Missed = true
+		return ev
+	}
+	if m.Power == 0 || m.Class == Status {
+		return ev
+	}
+
+	ev.Damage, ev.Critical, ev.Effectiveness = b.damage(attacker, defender, m)
+	defender.HP = max(defender.HP-ev.Damage, 0)
+	ev.HPLeft = defender.HP
+	ev.Fainted = defender.Fainted()
+	return ev
+}
+
+// critChance is the 1 in n chance of a critical hit
+const critChance = 24
+
+// damage calculates the damage of a move with the formula of the games
+// from generation V on
+func (b *Battle) damage(attacker, defender *Battler, m Move) (damage int, crit bool, eff float64) {
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if m.Class == Special {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+
+	damage = BaseDamage(attacker.Level, m.Power, attack, defense)
+
+	crit = b.rng.IntN(critChance) == 0
+	if crit {
+		damage = damage * 3 / 2
+	}
+	// a random spread of 85% to 100%
+	damage = damage * (85 + b.rng.IntN(16)) / 100
+	if attacker.hasType(m.Type) {
+		damage = damage * 3 / 2
+	}
+
+	eff = Effectiveness(b.chart, m.Type, defender.Types)
+	damage = int(float64(damage) * eff)
+	if eff > 0 {
+		damage = max(damage, 1)
+	}
+	return damage, crit, eff
+}
+
+// BaseDamage is the damage before any modifiers of a move with the given
+// power, used by an attacker of level with attack against defense
+func BaseDamage(level, power, attack, defense int) int {
+	return (2*level/5+2)*power*attack/max(defense, 1)/50 + 2
+}
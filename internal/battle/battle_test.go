@@ -0,0 +1,258 @@
+package battle
+
+import (
+	"testing"
+)
+
+// scriptedRNG returns its values in order and fails the test when it
+// runs out or a value is out of range
+type scriptedRNG struct {
+	t      *testing.T
+	values []int
+}
+
+func (r *scriptedRNG) IntN(n int) int {
+	r.t.Helper()
+	if len(r.values) == 0 {
+		r.t.Fatalf("scriptedRNG ran out of values")
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	if v >= n {
+		r.t.Fatalf("scripted value %d out of range [0, %d)", v, n)
+	}
+	return v
+}
+
+// chart is a TypeChart for tests; pairs it doesn't list are neutral
+type chart map[[2]string]float64
+
+func (c chart) Multiplier(attacking, defending string) float64 {
+	if m, ok := c[[2]string{attacking, defending}]; ok {
+		return m
+	}
+	return 1
+}
+
+var testChart = chart{
+	{"electric", "water"}:  2,
+	{"electric", "flying"}: 2,
+	{"electric", "grass"}:  0.5,
+	{"electric", "ground"}: 0,
+}
+
+// rolls that give no critical hit and the highest damage roll
+var (
+	noCrit   = critChance - 1
+	maxRoll  = 15
+	minRoll  = 0
+	critRoll = 0
+)
+
+var thunderbolt = Move{Name: "thunderbolt", Type: "electric", Class: Special, Power: 90, Accuracy: 100}
+
+func newBattler(name string, types ...string) *Battler {
+	return &Battler{
+		Name:  name,
+		Level: 50,
+		Types: types,
+		Stats: Stats{HP: 150, Attack: 100, Defense: 100, SpecialAttack: 100, SpecialDefense: 100, Speed: 100},
+		HP:    150,
+	}
+}
+
+func TestBaseDamage(t *testing.T) {
+	cases := []struct {
+		level, power, attack, defense int
+		expected                      int
+	}{
+		{level: 50, power: 80, attack: 100, defense: 100, expected: 37},
+		{level: 5, power: 40, attack: 10, defense: 10, expected: 5},
+		{level: 100, power: 90, attack: 200, defense: 100, expected: 153},
+		// a defense of 0 must not divide by zero
+		{level: 1, power: 10, attack: 5, defense: 0, expected: 4},
+	}
+
+	for _, c := range cases {
+		if actual := BaseDamage(c.level, c.power, c.attack, c.defense); actual != c.expected {
+			t.Errorf("BaseDamage(%d, %d, %d, %d) = %d, expected %d", c.level, c.power, c.attack, c.defense, actual, c.expected)
+		}
+	}
+}
+
+func TestDamageModifiers(t *testing.T) {
+	// a level 50 special attack of 90 power with 100 vs 100 has a
+	// base damage of (22 * 90 * 100 / 100) / 50 + 2 = 41
+	cases := []struct {
+		name     string
+		attacker []string
+		defender []string
+		rolls    []int
+		damage   int
+		crit     bool
+		eff      float64
+	}{
+		{name: "neutral", attacker: []string{"normal"}, defender: []string{"normal"}, rolls: []int{0, noCrit, maxRoll}, damage: 41, eff: 1},
+		{name: "lowest roll", attacker: []string{"normal"}, defender: []string{"normal"}, rolls: []int{0, noCrit, minRoll}, damage: 34, eff: 1},
+		{name: "critical hit", attacker: []string{"normal"}, defender: []string{"normal"}, rolls: []int{0, critRoll, maxRoll}, damage: 61, crit: true, eff: 1},
+		{name: "stab", attacker: []string{"electric"}, defender: []string{"normal"}, rolls: []int{0, noCrit, maxRoll}, damage: 61, eff: 1},
+		{name: "super effective", attacker: []string{"normal"}, defender: []string{"water"}, rolls: []int{0, noCrit, maxRoll}, damage: 82, eff: 2},
+		{name: "double super effective with stab", attacker: []string{"electric"}, defender: []string{"water", "flying"}, rolls: []int{0, noCrit, maxRoll}, damage: 244, eff: 4},
+		{name: "not very effective", attacker: []string{"normal"}, defender: []string{"grass"}, rolls: []int{0, noCrit, maxRoll}, damage: 20, eff: 0.5},
+		{name: "immune", attacker: []string{"electric"}, defender: []string{"ground"}, rolls: []int{0, noCrit, maxRoll}, damage: 0, eff: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			attacker, defender := newBattler("pikachu", c.attacker...), newBattler("target", c.defender...)
+			defender.HP, defender.Stats.HP = 500, 500
+			b := New(attacker, defender, &scriptedRNG{t: t, values: c.rolls}, WithChart(testChart))
+
+			ev := b.attack(Player, thunderbolt)
+			if ev.Missed || ev.Damage != c.damage || ev.Critical != c.crit || ev.Effectiveness != c.eff {
+				t.Errorf("expected %d damage (crit %v, eff %v), got %+v", c.damage, c.crit, c.eff, ev)
+			}
+			if defender.HP != 500-c.damage || ev.HPLeft != defender.HP {
+				t.Errorf("expected defender to have %d HP, got %d", 500-c.damage, defender.HP)
+			}
+		})
+	}
+}
+
+func TestAccuracy(t *testing.T) {
+	slam := Move{Name: "slam", Type: "normal", Class: Physical, Power: 80, Accuracy: 75}
+	swift := Move{Name: "swift", Type: "normal", Class: Special, Power: 60}
+
+	cases := []struct {
+		name   string
+		move   Move
+		rolls  []int
+		missed bool
+	}{
+		{name: "hit", move: slam, rolls: []int{74, noCrit, maxRoll}, missed: false},
+		{name: "miss", move: slam, rolls: []int{75}, missed: true},
+		// no accuracy roll at all for moves that never miss
+		{name: "never misses", move: swift, rolls: []int{noCrit, maxRoll}, missed: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defender := newBattler("target", "normal")
+			b := New(newBattler("attacker", "fire"), defender, &scriptedRNG{t: t, values: c.rolls})
+
+			ev := b.attack(Player, c.move)
+			if ev.Missed != c.missed {
+				t.Errorf("expected missed = %v, got %+v", c.missed, ev)
+			}
+			if c.missed && (ev.Damage != 0 || defender.HP != defender.Stats.HP) {
+				t.Errorf("a missed move must not deal damage, got %+v", ev)
+			}
+		})
+	}
+}
+
+func TestTurnOrder(t *testing.T) {
+	tackle := Move{Name: "tackle", Type: "normal", Class: Physical, Power: 1}
+	quickAttack := Move{Name: "quick-attack", Type: "normal", Class: Physical, Power: 1, Priority: 1}
+	// each hit needs a crit roll and a damage roll
+	hits := []int{noCrit, maxRoll, noCrit, maxRoll}
+
+	cases := []struct {
+		name        string
+		playerSpeed int
+		foeSpeed    int
+		playerMove  Move
+		foeMove     Move
+		tieRoll     []int
+		first       Side
+	}{
+		{name: "faster player", playerSpeed: 90, foeSpeed: 50, playerMove: tackle, foeMove: tackle, first: Player},
+		{name: "faster foe", playerSpeed: 50, foeSpeed: 90, playerMove: tackle, foeMove: tackle, first: Foe},
+		{name: "priority beats speed", playerSpeed: 50, foeSpeed: 90, playerMove: quickAttack, foeMove: tackle, first: Player},
+		{name: "speed tie won", playerSpeed: 70, foeSpeed: 70, playerMove: tackle, foeMove: tackle, tieRoll: []int{0}, first: Player},
+		{name: "speed tie lost", playerSpeed: 70, foeSpeed: 70, playerMove: tackle, foeMove: tackle, tieRoll: []int{1}, first: Foe},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			player, foe := newBattler("player", "fire"), newBattler("foe", "water")
+			player.Stats.Speed, foe.Stats.Speed = c.playerSpeed, c.foeSpeed
+			player.Moves, foe.Moves = []Move{c.playerMove}, []Move{c.foeMove}
+			b := New(player, foe, &scriptedRNG{t: t, values: append(c.tieRoll, hits...)})
+
+			events := b.PlayTurn([2]int{0, 0})
+			if len(events) != 2 {
+				t.Fatalf("expected both sides to move, got %+v", events)
+			}
+			if events[0].Attacker != c.first || events[1].Attacker != c.first.Other() {
+				t.Errorf("expected %v to move first, got %+v", c.first, events)
+			}
+			if b.Turn != 1 || events[0].Turn != 1 {
+				t.Errorf("expected turn 1, got %d", b.Turn)
+			}
+		})
+	}
+}
+
+func TestFainting(t *testing.T) {
+	player, foe := newBattler("pikachu", "electric"), newBattler("gyarados", "water", "flying")
+	player.Stats.Speed = 120
+	player.Moves = []Move{thunderbolt}
+	foe.Moves = []Move{{Name: "bite", Type: "dark", Class: Physical, Power: 60, Accuracy: 100}}
+	foe.HP = 10
+
+	b := New(player, foe, &scriptedRNG{t: t, values: []int{0, noCrit, maxRoll}}, WithChart(testChart))
+	events := b.PlayTurn([2]int{0, 0})
+
+	// the foe faints before it gets to move
+	if len(events) != 1 || !events[0].Fainted || events[0].Attacker != Player {
+		t.Fatalf("expected only the player's fainting hit, got %+v", events)
+	}
+	if foe.HP != 0 || !foe.Fainted() {
+		t.Errorf("expected foe HP to stop at 0, got %d", foe.HP)
+	}
+	if winner, ok := b.Winner(); !ok || winner != Player {
+		t.Errorf("expected the player to win, got %v %v", winner, ok)
+	}
+	if events := b.PlayTurn([2]int{0, 0}); events != nil || b.Turn != 1 {
+		t.Errorf("expected no more turns after the battle is over, got %+v", events)
+	}
+}
+
+func TestRun(t *testing.T) {
+	player, foe := newBattler("player", "fire"), newBattler("foe", "water")
+	player.Stats.Speed = 120
+	player.Moves = []Move{{Name: "scratch", Type: "normal", Class: Physical, Power: 40}}
+	foe.Moves = []Move{{Name: "water-gun", Type: "water", Class: Special, Power: 40}}
+	player.HP, foe.HP = 30, 40
+
+	// scratch does (22 * 40) / 50 + 2 = 19 and water gun with stab
+	// 19 * 3 / 2 = 28: the foe survives two scratches with 2 HP and
+	// the player goes down to the second water gun
+	var values []int
+	for range 4 {
+		values = append(values, noCrit, maxRoll)
+	}
+	b := New(player, foe, &scriptedRNG{t: t, values: values})
+
+	events := b.Run(10)
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %+v", events)
+	}
+	if winner, ok := b.Winner(); !ok || winner != Foe {
+		t.Errorf("expected the foe to win, got %v %v (%+v)", winner, ok, events)
+	}
+	if b.Turn != 2 || foe.HP != 2 || player.HP != 0 || !events[3].Fainted {
+		t.Errorf("unexpected end of battle: turn %d, foe HP %d, player HP %d", b.Turn, foe.HP, player.HP)
+	}
+}
+
+func TestStruggle(t *testing.T) {
+	player, foe := newBattler("player", "normal"), newBattler("foe", "normal")
+	b := New(player, foe, &scriptedRNG{t: t, values: []int{0, noCrit, maxRoll, noCrit, maxRoll}})
+
+	events := b.Step()
+	if len(events) != 2 || events[0].Move != "struggle" || events[1].Move != "struggle" {
+		t.Errorf("expected battlers without moves to struggle, got %+v", events)
+	}
+}
@@ -0,0 +1,200 @@
+package pokeapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokecache"
+)
+
+const (
+	DefaultBaseURL = "https://pokeapi.co/api/v2/"
+	// cacheSweepInterval is how often the default cache drops
+	// expired responses
+	cacheSweepInterval = time.Minute
+	// cacheMaxBytes caps the default in-memory cache; a pokemon
+	// response alone can be a few hundred KB
+	cacheMaxBytes  = 64 << 20
+	defaultTimeout = 10 * time.Second
+)
+
+// Cache is the storage used by a Client to keep raw response bodies
+// keyed by request URL. pokecache.Cache satisfies it and the richer
+// TTLCache.
+type Cache interface {
+	Add(key string, val []byte)
+	Get(key string) ([]byte, bool)
+}
+
+// Client talks to a PokeAPI compatible server. Use NewClient to build one.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      Cache
+	// ownsCache is set when the client created its cache and so has
+	// to close it
+	ownsCache bool
+	ttl       TTLPolicy
+	retry     RetryPolicy
+	// sleep waits between retries; tests swap it out
+	sleep func(context.Context, time.Duration) error
+
+	limiter  *tokenBucket
+	inFlight chan struct{}
+	flights  flightGroup
+
+	statsMu   sync.Mutex
+	waitStats WaitStats
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	baseURL    string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    *time.Duration
+	cache      Cache
+	ttl        *TTLPolicy
+	retry      *RetryPolicy
+
+	limiter     *tokenBucket
+	limiterSet  bool
+	maxInFlight *int
+}
+
+// WithBaseURL points the client at a different PokeAPI server, for example
+// a local mirror or an httptest server.
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the http.Client used for all requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the RoundTripper used for all requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout of a single request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.timeout = &timeout
+	}
+}
+
+// WithCache sets the cache used for response bodies.
+func WithCache(cache Cache) Option {
+	return func(cfg *clientConfig) {
+		cfg.cache = cache
+	}
+}
+
+// NewClient creates a Client. Without options it talks to the public
+// PokeAPI and caches responses in memory.
+func NewClient(opts ...Option) *Client {
+	cfg := clientConfig{
+		baseURL: DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// copy the caller's http.Client so our timeout/transport
+	// settings don't leak back into it
+	httpClient := &http.Client{Timeout: defaultTimeout}
+	if cfg.httpClient != nil {
+		*httpClient = *cfg.httpClient
+	}
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+	if cfg.timeout != nil {
+		httpClient.Timeout = *cfg.timeout
+	}
+
+	ttl := DefaultTTLPolicy
+	if cfg.ttl != nil {
+		ttl = *cfg.ttl
+	}
+	ownsCache := cfg.cache == nil
+	if ownsCache {
+		cfg.cache = NewMemoryCache(ttl)
+	}
+
+	retry := DefaultRetryPolicy
+	if cfg.retry != nil {
+		retry = *cfg.retry
+	}
+
+	if !cfg.limiterSet {
+		cfg.limiter = newTokenBucket(defaultRatePerSecond, defaultBurst)
+	}
+	maxInFlight := defaultMaxInFlight
+	if cfg.maxInFlight != nil {
+		maxInFlight = *cfg.maxInFlight
+	}
+	var inFlight chan struct{}
+	if maxInFlight > 0 {
+		inFlight = make(chan struct{}, maxInFlight)
+	}
+
+	if !strings.HasSuffix(cfg.baseURL, "/") {
+		cfg.baseURL += "/"
+	}
+
+	return &Client{
+		baseURL:    cfg.baseURL,
+		httpClient: httpClient,
+		cache:      cfg.cache,
+		ownsCache:  ownsCache,
+		ttl:        ttl,
+		retry:      retry,
+		sleep:      sleepCtx,
+		limiter:    cfg.limiter,
+		inFlight:   inFlight,
+	}
+}
+
+// NewMemoryCache creates the in-memory cache a Client uses by default,
+// for callers that want to put it in front of another tier
+func NewMemoryCache(policy TTLPolicy) *pokecache.Cache {
+	return pokecache.NewCache(policy.Default,
+		pokecache.WithSweepInterval(cacheSweepInterval),
+		pokecache.WithMaxBytes(cacheMaxBytes),
+	)
+}
+
+// Close stops the background work of the cache the client created for
+// itself. A cache passed in with WithCache belongs to the caller and is
+// left alone.
+func (c *Client) Close() error {
+	if closer, ok := c.cache.(io.Closer); ok && c.ownsCache {
+		return closer.Close()
+	}
+	return nil
+}
+
+// BaseURL returns the root URL all endpoints are built from.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// endpoint returns the URL of a resource list, e.g. .../location-area/
+func (c *Client) endpoint(resource string) string {
+	return c.baseURL + resource + "/"
+}
@@ -0,0 +1,266 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokecache"
+)
+
+// newTestServer serves canned JSON bodies keyed by request path and counts
+// how many requests reached it
+func newTestServer(t *testing.T, bodies map[string]string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	hits := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, hits
+}
+
+// newTestClient creates a Client that is closed when the test ends, so
+// its default cache doesn't outlive the test
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	client := NewClient(opts...)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestGetPokemon(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/": `{"id": 25, "name": "pikachu", "base_experience": 112}`,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	for i := 0; i < 2; i++ {
+		stats, err := client.GetPokemon(context.Background(), "pikachu")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stats.ID != 25 || stats.Name != "pikachu" || stats.BaseExperience != 112 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	}
+
+	// the second call should have been served from the cache
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 request to the server, got %d", got)
+	}
+}
+
+func TestListLocationAreas(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/location-area/": `{"count": 2, "next": "next-page", "results": [{"name": "canalave-city-area"}, {"name": "eterna-city-area"}]}`,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	page, err := client.ListLocationAreas(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Next != "next-page" || len(page.Results) != 2 || page.Results[1].Name != "eterna-city-area" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
+
+func TestGetLocationArea(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/location-area/canalave-city-area/": `{"name": "canalave-city-area", "pokemon_encounters": [{"pokemon": {"name": "tentacool"}}]}`,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	details, err := client.GetLocationArea(context.Background(), "canalave-city-area")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(details.PokemonList) != 1 || details.PokemonList[0].Pokemon.Name != "tentacool" {
+		t.Errorf("unexpected details: %+v", details)
+	}
+
+	_, err = client.GetLocationArea(context.Background(), "nowhere")
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrUpstream) {
+		t.Errorf("expected ErrNotFound for an unknown area, got %v", err)
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 StatusError, got %v", err)
+	}
+}
+
+type countingTransport struct {
+	calls atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/pokemon/ditto/": `{"id": 132, "name": "ditto"}`,
+	})
+	transport := &countingTransport{}
+	client := newTestClient(t, WithBaseURL(srv.URL), WithTransport(transport))
+
+	if _, err := client.GetPokemon(context.Background(), "ditto"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := transport.calls.Load(); got != 1 {
+		t.Errorf("expected custom transport to be used once, got %d", got)
+	}
+}
+
+func TestFetchDecodeError(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/pokemon/missingno/": `{"id": "not a number"`,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPokemon(context.Background(), "missingno"); !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	}
+
+	// a body that failed to decode must not be cached
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected 2 requests to the server, got %d", got)
+	}
+}
+
+func TestFetchCanceled(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(block) })
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNamedResourceID(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected int
+	}{
+		{url: "https://pokeapi.co/api/v2/pokemon/25/", expected: 25},
+		{url: "https://pokeapi.co/api/v2/pokemon/10034", expected: 10034},
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu/", expected: 0},
+		{url: "", expected: 0},
+	}
+
+	for _, c := range cases {
+		if actual := (NamedResource{URL: c.url}).ID(); actual != c.expected {
+			t.Errorf("ID of %q = %d, expected %d", c.url, actual, c.expected)
+		}
+	}
+}
+
+// closeCountingCache is a Cache that counts calls to Close
+type closeCountingCache struct {
+	ttlRecorder
+	closed int
+}
+
+func (c *closeCountingCache) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestClientClose(t *testing.T) {
+	// the default cache is the client's to close
+	before := pokecache.Reapers()
+	if err := NewClient().Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := pokecache.Reapers(); n > before {
+		t.Errorf("expected Close to stop the default cache's reapLoop, %d running", n)
+	}
+
+	cache := &closeCountingCache{}
+	client := NewClient(WithCache(cache))
+	if err := client.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if cache.closed != 0 {
+		t.Errorf("expected a cache passed in with WithCache not to be closed")
+	}
+}
+
+func TestCacheStatsThroughClient(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/": `{"id": 25, "name": "pikachu"}`,
+	})
+	dir := t.TempDir()
+
+	// newSession builds the cache tiers of one run of the program
+	newSession := func() (*pokecache.TieredCache, *Client) {
+		disk, err := pokecache.NewDiskCache(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache := pokecache.NewTieredCache(NewMemoryCache(DefaultTTLPolicy), disk)
+		t.Cleanup(func() { cache.Close() })
+		return cache, newTestClient(t, WithBaseURL(srv.URL), WithCache(cache))
+	}
+	getTwice := func(client *Client) {
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	// the first run fetches pikachu once and then finds it in memory,
+	// the second finds it on disk and then in memory
+	first, client := newSession()
+	getTwice(client)
+	second, client := newSession()
+	getTwice(client)
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request, got %d", got)
+	}
+
+	cases := []struct {
+		name         string
+		stats        pokecache.Stats
+		hits, misses int
+	}{
+		{name: "first run", stats: first.Stats(), hits: 1, misses: 1},
+		{name: "first run memory", stats: first.Memory.Stats(), hits: 1, misses: 1},
+		{name: "first run disk", stats: first.Disk.Stats(), hits: 0, misses: 1},
+		{name: "second run", stats: second.Stats(), hits: 2, misses: 0},
+		{name: "second run memory", stats: second.Memory.Stats(), hits: 1, misses: 1},
+		{name: "second run disk", stats: second.Disk.Stats(), hits: 1, misses: 0},
+	}
+	for _, c := range cases {
+		if c.stats.Hits != c.hits || c.stats.Misses != c.misses {
+			t.Errorf("%s: expected %d hits and %d misses, got %+v", c.name, c.hits, c.misses, c.stats)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNotFound is returned when PokeAPI has no such resource,
+	// e.g. a misspelled pokemon or location area name
+	ErrNotFound = errors.New("pokeapi: resource not found")
+	// ErrRateLimited is returned when PokeAPI asks us to slow down
+	ErrRateLimited = errors.New("pokeapi: rate limited")
+	// ErrUpstream is returned for any non-2xx response
+	ErrUpstream = errors.New("pokeapi: upstream error")
+	// ErrDecode is returned when a response body is not the JSON we expected
+	ErrDecode = errors.New("pokeapi: failed to decode response")
+)
+
+// StatusError describes a non-2xx response. It matches ErrUpstream and,
+// depending on the status code, ErrNotFound or ErrRateLimited.
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("pokeapi: %s returned status %d", e.URL, e.StatusCode)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrUpstream:
+		return true
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// DecodeError wraps the json error for a body that could not be decoded.
+// It matches ErrDecode.
+type DecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("pokeapi: failed to decode response from %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
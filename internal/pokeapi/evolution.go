@@ -0,0 +1,87 @@
+package pokeapi
+
+import (
+	"context"
+	"strconv"
+)
+
+// EvolutionChain is a family of species and how they evolve into each
+// other, see https://pokeapi.co/docs/v2#evolution-chains
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink is one species in an evolution chain together with the
+// species it can evolve into
+type ChainLink struct {
+	IsBaby  bool          `json:"is_baby"`
+	Species NamedResource `json:"species"`
+	// EvolutionDetails are the ways this species can be reached from
+	// the link above it; any one of them is enough
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail is one set of conditions for an evolution. Conditions
+// PokeAPI leaves null are zero.
+type EvolutionDetail struct {
+	// Trigger is e.g. "level-up", "use-item" or "trade"
+	Trigger      NamedResource `json:"trigger"`
+	MinLevel     int           `json:"min_level"`
+	Item         NamedResource `json:"item"`
+	HeldItem     NamedResource `json:"held_item"`
+	MinHappiness int           `json:"min_happiness"`
+	// TimeOfDay is "day", "night" or empty for any time
+	TimeOfDay string `json:"time_of_day"`
+
+	// conditions the game doesn't check yet
+	Gender                *int          `json:"gender"`
+	KnownMove             NamedResource `json:"known_move"`
+	KnownMoveType         NamedResource `json:"known_move_type"`
+	Location              NamedResource `json:"location"`
+	MinAffection          int           `json:"min_affection"`
+	MinBeauty             int           `json:"min_beauty"`
+	NeedsOverworldRain    bool          `json:"needs_overworld_rain"`
+	PartySpecies          NamedResource `json:"party_species"`
+	PartyType             NamedResource `json:"party_type"`
+	RelativePhysicalStats *int          `json:"relative_physical_stats"`
+	TradeSpecies          NamedResource `json:"trade_species"`
+	TurnUpsideDown        bool          `json:"turn_upside_down"`
+}
+
+// GetEvolutionChain fetches an evolution chain by id. The id of a species'
+// chain is PokemonSpecies.EvolutionChainID().
+func (c *Client) GetEvolutionChain(ctx context.Context, id int) (EvolutionChain, error) {
+	url := c.endpoint(ResourceEvolutionChain) + strconv.Itoa(id) + "/"
+	return fetch[EvolutionChain](ctx, c, url)
+}
+
+// EvolutionChainID returns the id of the species' evolution chain, or 0
+// if it has none
+func (s PokemonSpecies) EvolutionChainID() int {
+	return NamedResource{URL: s.EvolutionChain.URL}.ID()
+}
+
+// Find returns the link of a species within the chain, or nil if the
+// species is not part of it
+func (l *ChainLink) Find(species string) *ChainLink {
+	if l.Species.Name == species {
+		return l
+	}
+	for i := range l.EvolvesTo {
+		if found := l.EvolvesTo[i].Find(species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Unsupported reports whether the evolution needs a condition beyond
+// level, item, trade, held item, friendship and time of day
+func (d EvolutionDetail) Unsupported() bool {
+	return d.Gender != nil || d.TradeSpecies.Name != "" || d.KnownMove.Name != "" || d.KnownMoveType.Name != "" ||
+		d.Location.Name != "" || d.MinAffection > 0 || d.MinBeauty > 0 ||
+		d.NeedsOverworldRain || d.PartySpecies.Name != "" || d.PartyType.Name != "" ||
+		d.RelativePhysicalStats != nil || d.TurnUpsideDown
+}
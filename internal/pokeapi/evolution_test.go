@@ -0,0 +1,70 @@
+package pokeapi
+
+import (
+	"context"
+	"testing"
+)
+
+// a trimmed down version of the eevee chain
+const eeveeChain = `{
+	"id": 67,
+	"chain": {
+		"is_baby": false,
+		"species": {"name": "eevee", "url": "https://pokeapi.co/api/v2/pokemon-species/133/"},
+		"evolution_details": [],
+		"evolves_to": [
+			{
+				"species": {"name": "vaporeon"},
+				"evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null, "time_of_day": ""}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "espeon"},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "leafeon"},
+				"evolution_details": [
+					{"trigger": {"name": "level-up"}, "location": {"name": "eterna-forest"}},
+					{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}
+				],
+				"evolves_to": []
+			}
+		]
+	}
+}`
+
+func TestGetEvolutionChain(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/evolution-chain/67/": eeveeChain,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	species := PokemonSpecies{}
+	species.EvolutionChain.URL = "https://pokeapi.co/api/v2/evolution-chain/67/"
+	chain, err := client.GetEvolutionChain(context.Background(), species.EvolutionChainID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if chain.ID != 67 || chain.Chain.Species.Name != "eevee" || len(chain.Chain.EvolvesTo) != 3 {
+		t.Fatalf("unexpected chain: %+v", chain)
+	}
+
+	espeon := chain.Chain.Find("espeon")
+	if espeon == nil {
+		t.Fatal("expected to find espeon in the chain")
+	}
+	if d := espeon.EvolutionDetails[0]; d.Trigger.Name != "level-up" || d.MinHappiness != 160 || d.TimeOfDay != "day" || d.MinLevel != 0 {
+		t.Errorf("unexpected espeon details: %+v", d)
+	}
+	if chain.Chain.Find("pikachu") != nil {
+		t.Error("expected pikachu not to be in the chain")
+	}
+
+	leafeon := chain.Chain.Find("leafeon")
+	if !leafeon.EvolutionDetails[0].Unsupported() || leafeon.EvolutionDetails[1].Unsupported() {
+		t.Errorf("expected only the location evolution to be unsupported: %+v", leafeon.EvolutionDetails)
+	}
+}
@@ -0,0 +1,113 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// fetch is the single path every resource goes through: look the url up in
+// the cache, otherwise GET it, decode it into T and cache the raw body
+func fetch[T any](ctx context.Context, c *Client, url string) (T, error) {
+	var results T
+
+	// concurrent callers for the same url share one cache lookup, and on
+	// a miss one request and one cache insert; only the caller that ran
+	// the flight has results decoded already. Looking the url up inside
+	// the flight means a caller can't miss the cache just before another
+	// flight fills it, and each fetch counts once in the cache's stats.
+	bytesBody, shared, err := c.flights.do(ctx, url, func() ([]byte, error) {
+		if cacheData, found := c.cache.Get(url); found {
+			if err := json.Unmarshal(cacheData, &results); err != nil {
+				return nil, &DecodeError{URL: url, Err: err}
+			}
+			return cacheData, nil
+		}
+
+		bytesBody, err := c.get(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		// only cache bodies that decode, otherwise a bad response
+		// would keep failing until the cache reaps it
+		if err := json.Unmarshal(bytesBody, &results); err != nil {
+			return nil, &DecodeError{URL: url, Err: err}
+		}
+
+		c.cacheAdd(url, bytesBody)
+		return bytesBody, nil
+	})
+	if err != nil {
+		return results, err
+	}
+
+	if shared {
+		if err := json.Unmarshal(bytesBody, &results); err != nil {
+			return results, &DecodeError{URL: url, Err: err}
+		}
+	}
+
+	return results, nil
+}
+
+// get GETs url and returns the body, retrying according to the client's
+// retry policy
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	attempts := max(c.retry.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		body, res, err := c.getOnce(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+
+		retryable := retryableErr(ctx, err)
+		if res != nil {
+			retryable = retryableStatus(res.StatusCode)
+		}
+		if !retryable || attempt >= attempts {
+			return nil, err
+		}
+
+		if err := c.sleep(ctx, c.retry.delayFor(attempt, res)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// getOnce makes a single request. The response is returned alongside a
+// status error so the caller can look at its status and headers.
+func (c *Client) getOnce(ctx context.Context, url string) ([]byte, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http req failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 299 {
+		// drain so the connection can be reused
+		io.Copy(io.Discard, res.Body)
+		return nil, res, &StatusError{StatusCode: res.StatusCode, URL: url}
+	}
+
+	bytesBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return bytesBody, res, nil
+}
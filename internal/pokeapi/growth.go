@@ -0,0 +1,56 @@
+package pokeapi
+
+import (
+	"context"
+)
+
+// GrowthRate is how fast a species levels up,
+// see https://pokeapi.co/docs/v2#growth-rates
+type GrowthRate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	// Levels lists the total experience needed for every level, 1 to 100
+	Levels []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+// GetGrowthRate fetches a growth rate by name or id, e.g. "medium-slow"
+func (c *Client) GetGrowthRate(ctx context.Context, nameOrID string) (GrowthRate, error) {
+	url := c.endpoint(ResourceGrowthRate) + nameOrID + "/"
+	return fetch[GrowthRate](ctx, c, url)
+}
+
+// ExperienceFor returns the total experience a pokemon needs to reach
+// level. Levels outside the table are clamped to it.
+func (g GrowthRate) ExperienceFor(level int) int {
+	exp := 0
+	for _, l := range g.Levels {
+		if l.Level <= level {
+			exp = max(exp, l.Experience)
+		}
+	}
+	return exp
+}
+
+// LevelFor returns the level a pokemon with exp total experience is at
+func (g GrowthRate) LevelFor(exp int) int {
+	level := 1
+	for _, l := range g.Levels {
+		if l.Experience <= exp {
+			level = max(level, l.Level)
+		}
+	}
+	return level
+}
+
+// MaxLevel returns the highest level in the table
+func (g GrowthRate) MaxLevel() int {
+	top := 1
+	for _, l := range g.Levels {
+		top = max(top, l.Level)
+	}
+	return top
+}
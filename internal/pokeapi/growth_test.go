@@ -0,0 +1,67 @@
+package pokeapi
+
+import (
+	"context"
+	"testing"
+)
+
+// the first levels of the medium-fast (n^3) growth rate
+const mediumGrowthRate = `{
+	"id": 2,
+	"name": "medium",
+	"formula": "x^3",
+	"levels": [
+		{"level": 4, "experience": 64},
+		{"level": 3, "experience": 27},
+		{"level": 2, "experience": 8},
+		{"level": 1, "experience": 0},
+		{"level": 5, "experience": 125}
+	]
+}`
+
+func TestGrowthRate(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/growth-rate/medium/": mediumGrowthRate,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	rate, err := client.GetGrowthRate(context.Background(), "medium")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate.Name != "medium" || rate.MaxLevel() != 5 {
+		t.Errorf("unexpected growth rate: %+v", rate)
+	}
+
+	cases := []struct {
+		level int
+		exp   int
+	}{
+		{level: 1, exp: 0},
+		{level: 3, exp: 27},
+		{level: 5, exp: 125},
+		// past the end of the table
+		{level: 9, exp: 125},
+	}
+	for _, c := range cases {
+		if actual := rate.ExperienceFor(c.level); actual != c.exp {
+			t.Errorf("ExperienceFor(%d) = %d, expected %d", c.level, actual, c.exp)
+		}
+	}
+
+	levels := []struct {
+		exp   int
+		level int
+	}{
+		{exp: 0, level: 1},
+		{exp: 7, level: 1},
+		{exp: 8, level: 2},
+		{exp: 63, level: 3},
+		{exp: 1000, level: 5},
+	}
+	for _, c := range levels {
+		if actual := rate.LevelFor(c.exp); actual != c.level {
+			t.Errorf("LevelFor(%d) = %d, expected %d", c.exp, actual, c.level)
+		}
+	}
+}
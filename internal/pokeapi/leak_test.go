@@ -0,0 +1,12 @@
+package pokeapi
+
+import (
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/testutil"
+)
+
+// TestMain fails the package if any test leaves a client's cache running
+func TestMain(m *testing.M) {
+	testutil.CheckReapers(m)
+}
@@ -0,0 +1,81 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// names of the list endpoints, for use with List and ListPage
+const (
+	ResourceLocationArea   = "location-area"
+	ResourcePokemon        = "pokemon"
+	ResourcePokemonSpecies = "pokemon-species"
+	ResourceType           = "type"
+	ResourceItem           = "item"
+	ResourceMove           = "move"
+	ResourceGrowthRate     = "growth-rate"
+	ResourceEvolutionChain = "evolution-chain"
+)
+
+// DefaultPageSize is the page size PokeAPI itself uses
+const DefaultPageSize = 20
+
+// ListPage fetches a single page of a list endpoint such as "pokemon" or
+// "location-area".
+func (c *Client) ListPage(ctx context.Context, resource string, limit, offset int) (ResourceList, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	url := fmt.Sprintf("%s?offset=%d&limit=%d", c.endpoint(resource), max(offset, 0), limit)
+	return fetch[ResourceList](ctx, c, url)
+}
+
+// List walks every entry of a list endpoint, fetching pageSize entries at
+// a time as the loop advances. An error ends the iteration after it has
+// been yielded.
+//
+//	for res, err := range client.List(ctx, pokeapi.ResourceType, 50) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(res.Name)
+//	}
+func (c *Client) List(ctx context.Context, resource string, pageSize int) iter.Seq2[NamedResource, error] {
+	return func(yield func(NamedResource, error) bool) {
+		offset := 0
+		for {
+			page, err := c.ListPage(ctx, resource, pageSize, offset)
+			if err != nil {
+				yield(NamedResource{}, err)
+				return
+			}
+
+			for _, res := range page.Results {
+				if !yield(res, nil) {
+					return
+				}
+			}
+
+			// the offset is tracked here rather than following page.Next
+			// so a mirror handing out absolute pokeapi.co links still works
+			offset += len(page.Results)
+			if page.Next == "" || len(page.Results) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Collect gathers everything a List iterator yields, stopping at the
+// first error.
+func Collect(seq iter.Seq2[NamedResource, error]) ([]NamedResource, error) {
+	var all []NamedResource
+	for res, err := range seq {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, res)
+	}
+	return all, nil
+}
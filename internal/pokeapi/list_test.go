@@ -0,0 +1,107 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newListServer serves a "type" list endpoint with total entries named
+// type-0, type-1, ... honoring offset and limit like PokeAPI does
+func newListServer(t *testing.T, total int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	pages := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/type/" {
+			http.NotFound(w, r)
+			return
+		}
+		pages.Add(1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		page := ResourceList{Count: total}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page.Results = append(page.Results, NamedResource{Name: fmt.Sprintf("type-%d", i)})
+		}
+		if offset+limit < total {
+			page.Next = fmt.Sprintf("https://pokeapi.co/api/v2/type/?offset=%d&limit=%d", offset+limit, limit)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, pages
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		total     int
+		pageSize  int
+		wantPages int32
+	}{
+		{total: 0, pageSize: 5, wantPages: 1},
+		{total: 4, pageSize: 5, wantPages: 1},
+		{total: 10, pageSize: 5, wantPages: 2},
+		{total: 21, pageSize: 5, wantPages: 5},
+		{total: 21, pageSize: 0, wantPages: 2},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%d by %d", c.total, c.pageSize), func(t *testing.T) {
+			srv, pages := newListServer(t, c.total)
+			client := newTestClient(t, WithBaseURL(srv.URL))
+
+			all, err := Collect(client.List(context.Background(), ResourceType, c.pageSize))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(all) != c.total {
+				t.Fatalf("expected %d entries, got %d", c.total, len(all))
+			}
+			for i, res := range all {
+				if res.Name != fmt.Sprintf("type-%d", i) {
+					t.Errorf("entry %d out of order: %s", i, res.Name)
+				}
+			}
+			if got := pages.Load(); got != c.wantPages {
+				t.Errorf("expected %d page requests, got %d", c.wantPages, got)
+			}
+		})
+	}
+}
+
+func TestListStopsEarly(t *testing.T) {
+	srv, pages := newListServer(t, 100)
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	seen := 0
+	for _, err := range client.List(context.Background(), ResourceType, 10) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seen++
+		if seen == 15 {
+			break
+		}
+	}
+
+	if got := pages.Load(); got != 2 {
+		t.Errorf("expected only 2 pages to be fetched, got %d", got)
+	}
+}
+
+func TestListError(t *testing.T) {
+	srv, _ := newListServer(t, 10)
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	_, err := Collect(client.List(context.Background(), "not-a-resource", 10))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
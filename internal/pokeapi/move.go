@@ -0,0 +1,123 @@
+package pokeapi
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+// Move learn methods, named like PokeAPI's move-learn-method resource
+const (
+	LearnLevelUp = "level-up"
+	LearnMachine = "machine"
+	LearnEgg     = "egg"
+	LearnTutor   = "tutor"
+)
+
+// Move is an attack, see https://pokeapi.co/docs/v2#moves. Power and
+// Accuracy are 0 where PokeAPI has null, for moves that deal no direct
+// damage or never miss.
+type Move struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Power       int           `json:"power"`
+	Accuracy    int           `json:"accuracy"`
+	PP          int           `json:"pp"`
+	Priority    int           `json:"priority"`
+	Type        NamedResource `json:"type"`
+	DamageClass NamedResource `json:"damage_class"`
+}
+
+// GetMove fetches a move by name or id, e.g. "thunderbolt"
+func (c *Client) GetMove(ctx context.Context, nameOrID string) (Move, error) {
+	url := c.endpoint(ResourceMove) + nameOrID + "/"
+	return fetch[Move](ctx, c, url)
+}
+
+// GetMoves fetches several moves at once, in the order of names. The
+// requests run concurrently within the client's rate and in-flight limits;
+// the first error is returned.
+func (c *Client) GetMoves(ctx context.Context, names []string) ([]Move, error) {
+	moves := make([]Move, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			moves[i], errs[i] = c.GetMove(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return moves, nil
+}
+
+// LearnedMove is a move a pokemon learns in one version group
+type LearnedMove struct {
+	Name         string
+	Method       string
+	VersionGroup string
+	// Level is the level it is learned at for LearnLevelUp, 0 otherwise
+	Level int
+}
+
+// LatestVersionGroup returns the newest version group the pokemon has
+// move data for
+func (p PokemonStats) LatestVersionGroup() string {
+	latest, latestID := "", 0
+	for _, m := range p.Moves {
+		for _, d := range m.VersionGroupDetails {
+			if id := (NamedResource{URL: d.VersionGroup.URL}).ID(); latest == "" || id > latestID {
+				latest, latestID = d.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// learnMethodOrder is the order Learnset sorts methods in; anything
+// else goes last
+var learnMethodOrder = []string{LearnLevelUp, LearnMachine, LearnEgg, LearnTutor}
+
+// Learnset returns the moves the pokemon learns in a version group,
+// optionally only by one method. Moves are sorted by method, then level,
+// then name.
+func (p PokemonStats) Learnset(versionGroup, method string) []LearnedMove {
+	var learnset []LearnedMove
+	for _, m := range p.Moves {
+		for _, d := range m.VersionGroupDetails {
+			if d.VersionGroup.Name != versionGroup || (method != "" && d.MoveLearnMethod.Name != method) {
+				continue
+			}
+			learnset = append(learnset, LearnedMove{
+				Name:         m.Move.Name,
+				Method:       d.MoveLearnMethod.Name,
+				VersionGroup: d.VersionGroup.Name,
+				Level:        d.LevelLearnedAt,
+			})
+		}
+	}
+
+	methodRank := func(method string) int {
+		if i := slices.Index(learnMethodOrder, method); i >= 0 {
+			return i
+		}
+		return len(learnMethodOrder)
+	}
+	slices.SortFunc(learnset, func(a, b LearnedMove) int {
+		return cmp.Or(
+			cmp.Compare(methodRank(a.Method), methodRank(b.Method)),
+			cmp.Compare(a.Level, b.Level),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return learnset
+}
@@ -0,0 +1,117 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGetMoves(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/move/thunderbolt/": `{"id": 85, "name": "thunderbolt", "power": 90, "accuracy": 100, "pp": 15, "priority": 0,
+			"type": {"name": "electric"}, "damage_class": {"name": "special"}}`,
+		"/move/swift/": `{"id": 129, "name": "swift", "power": 60, "accuracy": null, "pp": 20,
+			"type": {"name": "normal"}, "damage_class": {"name": "special"}}`,
+		"/move/growl/": `{"id": 45, "name": "growl", "power": null, "accuracy": 100, "pp": 40,
+			"type": {"name": "normal"}, "damage_class": {"name": "status"}}`,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	moves, err := client.GetMoves(context.Background(), []string{"growl", "thunderbolt", "swift", "growl"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moves) != 4 || moves[0].Name != "growl" || moves[1].Name != "thunderbolt" || moves[3].Name != "growl" {
+		t.Fatalf("expected moves in the order asked for, got %+v", moves)
+	}
+	if m := moves[1]; m.Power != 90 || m.Accuracy != 100 || m.PP != 15 || m.Type.Name != "electric" || m.DamageClass.Name != "special" {
+		t.Errorf("unexpected thunderbolt: %+v", m)
+	}
+	if moves[2].Accuracy != 0 || moves[0].Power != 0 {
+		t.Errorf("expected null accuracy and power to be 0, got %+v and %+v", moves[2], moves[0])
+	}
+	// the same move asked for twice is fetched once
+	if got := hits.Load(); got != 3 {
+		t.Errorf("expected 3 requests to the server, got %d", got)
+	}
+
+	if _, err := client.GetMoves(context.Background(), []string{"swift", "splash"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown move, got %v", err)
+	}
+}
+
+const pikachuMoves = `{"name": "pikachu", "moves": [
+	{"move": {"name": "thunderbolt"}, "version_group_details": [
+		{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+		{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+	]},
+	{"move": {"name": "thunder-shock"}, "version_group_details": [
+		{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+		{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+	]},
+	{"move": {"name": "growl"}, "version_group_details": [
+		{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+	]},
+	{"move": {"name": "thunder"}, "version_group_details": [
+		{"level_learned_at": 43, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+		{"level_learned_at": 50, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+	]},
+	{"move": {"name": "volt-tackle"}, "version_group_details": [
+		{"level_learned_at": 0, "move_learn_method": {"name": "egg"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+	]}
+]}`
+
+func TestLearnset(t *testing.T) {
+	var pikachu PokemonStats
+	if err := json.Unmarshal([]byte(pikachuMoves), &pikachu); err != nil {
+		t.Fatal(err)
+	}
+
+	if latest := pikachu.LatestVersionGroup(); latest != "x-y" {
+		t.Errorf("expected x-y to be the latest version group, got %q", latest)
+	}
+
+	cases := []struct {
+		name         string
+		versionGroup string
+		method       string
+		expected     []LearnedMove
+	}{
+		{
+			name:         "every method",
+			versionGroup: "x-y",
+			expected: []LearnedMove{
+				{Name: "growl", Method: LearnLevelUp, VersionGroup: "x-y", Level: 1},
+				{Name: "thunder-shock", Method: LearnLevelUp, VersionGroup: "x-y", Level: 1},
+				{Name: "thunder", Method: LearnLevelUp, VersionGroup: "x-y", Level: 50},
+				{Name: "thunderbolt", Method: LearnMachine, VersionGroup: "x-y"},
+				{Name: "volt-tackle", Method: LearnEgg, VersionGroup: "x-y"},
+			},
+		},
+		{
+			name:         "level-up in an old version group",
+			versionGroup: "red-blue",
+			method:       LearnLevelUp,
+			expected: []LearnedMove{
+				{Name: "thunder-shock", Method: LearnLevelUp, VersionGroup: "red-blue", Level: 1},
+				{Name: "thunder", Method: LearnLevelUp, VersionGroup: "red-blue", Level: 43},
+			},
+		},
+		{name: "tutor", versionGroup: "x-y", method: LearnTutor, expected: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			learnset := pikachu.Learnset(c.versionGroup, c.method)
+			if len(learnset) != len(c.expected) {
+				t.Fatalf("expected %+v, got %+v", c.expected, learnset)
+			}
+			for i := range learnset {
+				if learnset[i] != c.expected[i] {
+					t.Errorf("move %d: expected %+v, got %+v", i, c.expected[i], learnset[i])
+				}
+			}
+		})
+	}
+}
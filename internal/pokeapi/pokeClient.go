@@ -1,29 +1,11 @@
 package pokeapi
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"time"
-
-	"github.com/snyderg13/pokedex/internal/pokecache"
-)
-
-const (
-	pokeAPIBaseURL       = "https://pokeapi.co/api/v2/"
-	locationAreaEndpoint = "https://pokeapi.co/api/v2/location-area/"
-	pokemonEndpoint      = "https://pokeapi.co/api/v2/pokemon/"
-	cacheReapRate        = 10 * time.Second
+	"context"
+	"strconv"
+	"strings"
 )
 
-var pokeAPIDebug = false
-var pokeAPICache pokecache.Cache
-
-func Init() {
-	pokeAPICache = pokecache.NewCache(cacheReapRate)
-}
-
 // {
 //   "count": 1089,
 //   "next": "https://pokeapi.co/api/v2/location-area/?offset=20&limit=20",
@@ -35,139 +17,47 @@ func Init() {
 //     },
 //     {
 
-type locArea struct {
+// NamedResource is a reference to another resource, as found in
+// list endpoints and throughout the other resources
+type NamedResource struct {
 	Name string `json:"name"`
-	Url  string `json:"url"`
+	URL  string `json:"url"`
 }
 
-type LocAreaResp struct {
-	Count   int       `json:"count"`
-	Next    string    `json:"next"`
-	Prev    string    `json:"previous"`
-	Results []locArea `json:"results"`
-}
-
-type PokeFetch interface {
-	DoGetData(string) (any, error)
-}
-
-func (l LocAreaResp) DoGetData(url string) (LocAreaResp, error) {
-	if url == "" {
-		url = locationAreaEndpoint
-	}
-
-	var results LocAreaResp
-	cacheData, found := pokeAPICache.Get(url)
-	if found {
-		err := json.Unmarshal(cacheData, &results)
-		if err != nil {
-			fmt.Println("failed to unmarshal cache data", err)
-		}
-
-		if pokeAPIDebug {
-			fmt.Println("CLIENT: Cache get was used")
-		}
-		return results, err
-	}
-
-	res, err := http.Get(url)
-	if err != nil {
-		// @TODO cleanup below lines
-		fmt.Println("http req failed")
-		fmt.Println(fmt.Errorf("http req failed: %w", err))
-		return results, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode > 299 {
-		return results, fmt.Errorf("status code (%d) > 299", res.StatusCode)
-	} else if res.StatusCode != 200 {
-		fmt.Printf("status code (%d) != 200\n", res.StatusCode)
-	}
-
-	// convert results to []byte and add to the cache
-	bytesBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println(err)
-		return results, err
-	}
-
-	// add data byte slice to cache
-	pokeAPICache.Add(url, bytesBody)
-	if pokeAPIDebug {
-		fmt.Println("CLIENT: Cache add was used")
+// ID returns the numeric id at the end of the resource's URL,
+// or 0 if the URL doesn't end in one
+func (r NamedResource) ID() int {
+	idx := strings.LastIndex(strings.TrimSuffix(r.URL, "/"), "/")
+	if idx < 0 {
+		return 0
 	}
-
-	// unmarshal into the LocAreaResp to return to the caller
-	err = json.Unmarshal(bytesBody, &results)
+	id, err := strconv.Atoi(strings.TrimSuffix(r.URL[idx+1:], "/"))
 	if err != nil {
-		fmt.Println("failed to unmarshal cache data", err)
+		return 0
 	}
-
-	return results, nil
+	return id
 }
 
-func GetLocationAreas(locURL string) (LocAreaResp, error) {
-	if locURL == "" {
-		locURL = locationAreaEndpoint
-	}
-
-	var results LocAreaResp
-	cacheData, found := pokeAPICache.Get(locURL)
-	if found {
-		err := json.Unmarshal(cacheData, &results)
-		if err != nil {
-			fmt.Println("failed to unmarshal cache data", err)
-		}
-
-		if pokeAPIDebug {
-			fmt.Println("CLIENT: Cache get was used")
-		}
-		return results, err
-	}
-
-	res, err := http.Get(locURL)
-	if err != nil {
-		// @TODO cleanup below lines
-		fmt.Println("http req failed")
-		fmt.Println(fmt.Errorf("http req failed: %w", err))
-		return LocAreaResp{}, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode > 299 {
-		return LocAreaResp{}, fmt.Errorf("status code (%d) > 299", res.StatusCode)
-	} else if res.StatusCode != 200 {
-		fmt.Printf("status code (%d) != 200\n", res.StatusCode)
-	}
-
-	// convert results to []byte and add to the cache
-	bytesBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println(err)
-		return LocAreaResp{}, err
-	}
-
-	// add data byte slice to cache
-	pokeAPICache.Add(locURL, bytesBody)
-	if pokeAPIDebug {
-		fmt.Println("CLIENT: Cache add was used")
-	}
+// ResourceList is a single page of any list endpoint
+type ResourceList struct {
+	Count   int             `json:"count"`
+	Next    string          `json:"next"`
+	Prev    string          `json:"previous"`
+	Results []NamedResource `json:"results"`
+}
 
-	// unmarshal into the LocAreaResp to return to the caller
-	err = json.Unmarshal(bytesBody, &results)
-	if err != nil {
-		fmt.Println("failed to unmarshal cache data", err)
+// ListLocationAreas fetches one page of location areas. An empty pageURL
+// fetches the first page.
+func (c *Client) ListLocationAreas(ctx context.Context, pageURL string) (ResourceList, error) {
+	url := pageURL
+	if url == "" {
+		url = c.endpoint(ResourceLocationArea)
 	}
-
-	return results, nil
+	return fetch[ResourceList](ctx, c, url)
 }
 
 type PokemonEncounters struct {
-	Pokemon struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"pokemon"`
+	Pokemon        NamedResource `json:"pokemon"`
 	VersionDetails []struct {
 		EncounterDetails []struct {
 			Chance          int   `json:"chance"`
@@ -219,57 +109,10 @@ type LocationDetails struct {
 }
 
 // @TODO add test cases for different commands
-func (l LocationDetails) DoGetData(locName string) (LocationDetails, error) {
-	url := locationAreaEndpoint + locName + "/"
-	var results LocationDetails
-	cacheData, found := pokeAPICache.Get(url)
-	if found {
-		err := json.Unmarshal(cacheData, &results)
-		if err != nil {
-			fmt.Println("failed to unmarshal cache data", err)
-		}
-
-		if pokeAPIDebug {
-			fmt.Println("CLIENT: Cache get was used")
-		}
-		return results, err
-	}
-
-	res, err := http.Get(url)
-	if err != nil {
-		// @TODO cleanup below lines
-		fmt.Println("http req failed")
-		fmt.Println(fmt.Errorf("http req failed: %w", err))
-		return results, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode > 299 {
-		return results, fmt.Errorf("status code (%d) > 299", res.StatusCode)
-	} else if res.StatusCode != 200 {
-		fmt.Printf("status code (%d) != 200\n", res.StatusCode)
-	}
-
-	// convert results to []byte and add to the cache
-	bytesBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println(err)
-		return results, err
-	}
-
-	// add data byte slice to cache
-	pokeAPICache.Add(url, bytesBody)
-	if pokeAPIDebug {
-		fmt.Println("CLIENT: Cache add was used")
-	}
-
-	// unmarshal into the LocAreaResp to return to the caller
-	err = json.Unmarshal(bytesBody, &results)
-	if err != nil {
-		fmt.Println("failed to unmarshal cache data", err)
-	}
-
-	return results, nil
+// GetLocationArea fetches the details of a single location area.
+func (c *Client) GetLocationArea(ctx context.Context, locName string) (LocationDetails, error) {
+	url := c.endpoint(ResourceLocationArea) + locName + "/"
+	return fetch[LocationDetails](ctx, c, url)
 }
 
 type PokemonStats struct {
@@ -345,12 +188,9 @@ type PokemonStats struct {
 			URL  string `json:"url"`
 		} `json:"generation"`
 	} `json:"past_abilities"`
-	PastTypes []any `json:"past_types"`
-	Species   struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
-	Sprites struct {
+	PastTypes []any         `json:"past_types"`
+	Species   NamedResource `json:"species"`
+	Sprites   struct {
 		BackDefault      string `json:"back_default"`
 		BackFemale       any    `json:"back_female"`
 		BackShiny        string `json:"back_shiny"`
@@ -536,76 +376,56 @@ type PokemonStats struct {
 			} `json:"generation-viii"`
 		} `json:"versions"`
 	} `json:"sprites"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	Weight int `json:"weight"`
+	Stats  []PokemonStat `json:"stats"`
+	Types  []PokemonType `json:"types"`
+	Weight int           `json:"weight"`
 }
 
-func (p PokemonStats) DoGetData(pokemonName string) (PokemonStats, error) {
-	url := pokemonEndpoint + pokemonName + "/"
-	if pokeAPIDebug {
-		fmt.Println("url = ", url)
-	}
-	var results PokemonStats
-	cacheData, found := pokeAPICache.Get(url)
-	if found {
-		err := json.Unmarshal(cacheData, &results)
-		if err != nil {
-			fmt.Println("failed to unmarshal cache data", err)
-		}
-
-		if pokeAPIDebug {
-			fmt.Println("CLIENT: Cache get was used")
-		}
-		return results, err
-	}
-
-	res, err := http.Get(url)
-	if err != nil {
-		// @TODO cleanup below lines
-		fmt.Println("http req failed")
-		fmt.Println(fmt.Errorf("http req failed: %w", err))
-		return results, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode > 299 {
-		return results, fmt.Errorf("status code (%d) > 299", res.StatusCode)
-	} else if res.StatusCode != 200 {
-		fmt.Printf("status code (%d) != 200\n", res.StatusCode)
-	}
+// PokemonStat is a base stat of a pokemon, e.g. hp or speed
+type PokemonStat struct {
+	BaseStat int           `json:"base_stat"`
+	Effort   int           `json:"effort"`
+	Stat     NamedResource `json:"stat"`
+}
 
-	// convert results to []byte and add to the cache
-	bytesBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println(err)
-		return results, err
-	}
+// PokemonType is one of a pokemon's types; Slot 1 is the primary type
+type PokemonType struct {
+	Slot int           `json:"slot"`
+	Type NamedResource `json:"type"`
+}
 
-	// add data byte slice to cache
-	pokeAPICache.Add(url, bytesBody)
-	if pokeAPIDebug {
-		fmt.Println("CLIENT: Cache add was used")
-	}
+// PokemonSummary is the part of a pokemon the game keeps for every
+// species caught. It leaves out the moves, sprites and the rest of a full
+// PokemonStats, which can run to hundreds of KB; fetch the pokemon again
+// for those. Its fields encode like the ones of PokemonStats, so a full
+// PokemonStats decodes into a PokemonSummary.
+type PokemonSummary struct {
+	ID             int           `json:"id"`
+	Name           string        `json:"name"`
+	BaseExperience int           `json:"base_experience"`
+	Height         int           `json:"height"`
+	Weight         int           `json:"weight"`
+	Species        NamedResource `json:"species"`
+	Stats          []PokemonStat `json:"stats"`
+	Types          []PokemonType `json:"types"`
+}
 
-	// unmarshal into the LocAreaResp to return to the caller
-	err = json.Unmarshal(bytesBody, &results)
-	if err != nil {
-		fmt.Println("failed to unmarshal cache data", err)
+// Summary returns the part of the pokemon kept for caught species
+func (p PokemonStats) Summary() PokemonSummary {
+	return PokemonSummary{
+		ID:             p.ID,
+		Name:           p.Name,
+		BaseExperience: p.BaseExperience,
+		Height:         p.Height,
+		Weight:         p.Weight,
+		Species:        p.Species,
+		Stats:          p.Stats,
+		Types:          p.Types,
 	}
+}
 
-	return results, nil
+// GetPokemon fetches the stats of a single pokemon by name or id.
+func (c *Client) GetPokemon(ctx context.Context, pokemonName string) (PokemonStats, error) {
+	url := c.endpoint(ResourcePokemon) + pokemonName + "/"
+	return fetch[PokemonStats](ctx, c, url)
 }
@@ -0,0 +1,151 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// PokeAPI has no hard limit but asks clients to be polite
+	defaultRatePerSecond = 10
+	defaultBurst         = 10
+	defaultMaxInFlight   = 4
+)
+
+// WithRateLimit limits the client to perSecond requests per second on
+// average, allowing short bursts of up to burst requests. A perSecond of
+// 0 or less disables rate limiting.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(cfg *clientConfig) {
+		cfg.limiter = newTokenBucket(perSecond, burst)
+		cfg.limiterSet = true
+	}
+}
+
+// WithMaxInFlight caps the number of requests the client has open at once.
+// A max of 0 or less removes the cap.
+func WithMaxInFlight(max int) Option {
+	return func(cfg *clientConfig) {
+		cfg.maxInFlight = &max
+	}
+}
+
+// WaitStats describes how long requests were held back by the rate
+// limiter and the in-flight cap before being sent.
+type WaitStats struct {
+	// Requests is the number of requests sent to the server
+	Requests int64
+	// Delayed is how many of those had to wait at all
+	Delayed int64
+	// TotalWait and MaxWait cover the time spent waiting
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// WaitStats returns a snapshot of the client's request wait metrics.
+func (c *Client) WaitStats() WaitStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	return c.waitStats
+}
+
+// acquire blocks until the client may send a request and returns a func
+// that must be called once the request is done
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	release := func() {}
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+			release = func() { <-c.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c.recordWait(time.Since(start))
+	return release, nil
+}
+
+func (c *Client) recordWait(waited time.Duration) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	c.waitStats.Requests++
+	// ignore the few microseconds it takes to get through
+	// an uncontended limiter
+	if waited < time.Millisecond {
+		return
+	}
+	c.waitStats.Delayed++
+	c.waitStats.TotalWait += waited
+	if waited > c.waitStats.MaxWait {
+		c.waitStats.MaxWait = waited
+	}
+}
+
+// tokenBucket refills at rate tokens per second up to burst tokens;
+// each request takes one token and waits for it if the bucket is empty
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perSecond float64, burst int) *tokenBucket {
+	if perSecond <= 0 {
+		return nil
+	}
+	burst = max(burst, 1)
+	return &tokenBucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long
+// the caller has to wait before that token is really available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a caller that stopped waiting
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := sleepCtx(ctx, b.reserve(time.Now())); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
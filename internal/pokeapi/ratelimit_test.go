@@ -0,0 +1,108 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	start := time.Now()
+	bucket := newTokenBucket(10, 2)
+
+	cases := []struct {
+		at       time.Duration
+		expected time.Duration
+	}{
+		// the burst is free
+		{at: 0, expected: 0},
+		{at: 0, expected: 0},
+		// then one token every 100ms
+		{at: 0, expected: 100 * time.Millisecond},
+		{at: 0, expected: 200 * time.Millisecond},
+		// after a long pause the bucket is full again, not overflowing
+		{at: 5 * time.Second, expected: 0},
+		{at: 5 * time.Second, expected: 0},
+		{at: 5 * time.Second, expected: 100 * time.Millisecond},
+	}
+
+	for i, c := range cases {
+		actual := bucket.reserve(start.Add(c.at))
+		if actual.Round(time.Millisecond) != c.expected {
+			t.Errorf("case %d: expected wait of %v, got %v", i, c.expected, actual)
+		}
+	}
+}
+
+func TestTokenBucketDisabled(t *testing.T) {
+	if newTokenBucket(0, 10) != nil {
+		t.Errorf("expected a zero rate to disable the limiter")
+	}
+}
+
+func TestRateLimitWaitStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := newTestClient(t, WithBaseURL(srv.URL), WithRateLimit(50, 1))
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.GetPokemon(context.Background(), fmt.Sprint(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 1 free request then 3 more at 20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected requests to be spread out, took %v", elapsed)
+	}
+	stats := client.WaitStats()
+	if stats.Requests != 4 {
+		t.Errorf("expected 4 requests, got %d", stats.Requests)
+	}
+	if stats.Delayed < 3 || stats.TotalWait < 50*time.Millisecond || stats.MaxWait == 0 {
+		t.Errorf("unexpected wait stats: %+v", stats)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	const maxInFlight = 2
+	var current, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := newTestClient(t, WithBaseURL(srv.URL), WithRateLimit(0, 0), WithMaxInFlight(maxInFlight))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPokemon(context.Background(), fmt.Sprint(i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > maxInFlight {
+		t.Errorf("expected at most %d requests in flight, saw %d", maxInFlight, got)
+	}
+}
@@ -0,0 +1,136 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries failed GET requests. Only
+// network errors and 429/5xx responses are retried; a 404 will not get
+// better by asking again.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	// Values below 1 are treated as 1 (no retries).
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps both the backoff and any Retry-After the server sends.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by clients created without WithRetry.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// NoRetry disables retries.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// WithRetry sets the retry policy used for all requests.
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.retry = &policy
+	}
+}
+
+// backoff returns how long to wait before retry number attempt (starting
+// at 1). It uses "equal jitter": half the exponential delay is fixed and
+// the other half is random, so clients that failed together don't all
+// retry together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	// a MaxDelay of 0 means uncapped, but doubling still has to stop
+	// before it overflows
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay) && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// delayFor picks the wait before the next attempt, preferring the server's
+// Retry-After header on 429 and 503 responses
+func (p RetryPolicy) delayFor(attempt int, res *http.Response) time.Duration {
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if after, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				after = p.MaxDelay
+			}
+			return after
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// parseRetryAfter understands both forms of the header:
+// a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		after := when.Sub(now)
+		if after < 0 {
+			after = 0
+		}
+		return after, true
+	}
+	return 0, false
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableErr reports whether a transport error is worth retrying;
+// the caller giving up is not
+func retryableErr(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// sleepCtx waits for d or until ctx is done
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
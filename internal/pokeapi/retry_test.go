@@ -0,0 +1,181 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer fails the first `failures` requests with status and
+// the given Retry-After header, then serves body
+func newFlakyServer(t *testing.T, failures int32, status int, retryAfter string, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	hits := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, hits
+}
+
+// recordSleeps replaces the client's sleep so tests don't wait
+// and returns the list of requested delays
+func recordSleeps(c *Client) *[]time.Duration {
+	var sleeps []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+	return &sleeps
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	cases := []struct {
+		failures int32
+		status   int
+	}{
+		{failures: 1, status: http.StatusInternalServerError},
+		{failures: 2, status: http.StatusBadGateway},
+		{failures: 3, status: http.StatusServiceUnavailable},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%d x %d", c.failures, c.status), func(t *testing.T) {
+			srv, hits := newFlakyServer(t, c.failures, c.status, "", `{"name": "pikachu"}`)
+			client := newTestClient(t, WithBaseURL(srv.URL), WithRetry(RetryPolicy{
+				MaxAttempts: 4,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    time.Second,
+			}))
+			sleeps := recordSleeps(client)
+
+			stats, err := client.GetPokemon(context.Background(), "pikachu")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stats.Name != "pikachu" {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+			if got := hits.Load(); got != c.failures+1 {
+				t.Errorf("expected %d requests, got %d", c.failures+1, got)
+			}
+			if len(*sleeps) != int(c.failures) {
+				t.Errorf("expected %d sleeps, got %d", c.failures, len(*sleeps))
+			}
+		})
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, hits := newFlakyServer(t, 10, http.StatusServiceUnavailable, "", `{}`)
+	client := newTestClient(t, WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 3}))
+	recordSleeps(client)
+
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+	if !errors.Is(err, ErrUpstream) {
+		t.Errorf("expected ErrUpstream, got %v", err)
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetrySkipsNotFound(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{})
+	client := newTestClient(t, WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 5}))
+	recordSleeps(client)
+
+	_, err := client.GetPokemon(context.Background(), "pikachuu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv, _ := newFlakyServer(t, 1, http.StatusTooManyRequests, "7", `{"name": "ditto"}`)
+	client := newTestClient(t, WithBaseURL(srv.URL), WithRetry(RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Minute,
+	}))
+	sleeps := recordSleeps(client)
+
+	if _, err := client.GetPokemon(context.Background(), "ditto"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 7*time.Second {
+		t.Errorf("expected a single 7s sleep, got %v", *sleeps)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "3", expected: 3 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: "Wed, 01 Jan 2025 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+
+	for _, c := range cases {
+		actual, ok := parseRetryAfter(c.value, now)
+		if ok != c.ok || actual != c.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v, %v", c.value, actual, ok, c.expected, c.ok)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	capped := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	// a MaxDelay of 0 doesn't cap the delay
+	uncapped := RetryPolicy{BaseDelay: 100 * time.Millisecond}
+	cases := []struct {
+		policy  RetryPolicy
+		attempt int
+		max     time.Duration
+	}{
+		{policy: capped, attempt: 1, max: 100 * time.Millisecond},
+		{policy: capped, attempt: 2, max: 200 * time.Millisecond},
+		{policy: capped, attempt: 3, max: 400 * time.Millisecond},
+		{policy: capped, attempt: 10, max: time.Second},
+		{policy: uncapped, attempt: 1, max: 100 * time.Millisecond},
+		{policy: uncapped, attempt: 2, max: 200 * time.Millisecond},
+		{policy: uncapped, attempt: 5, max: 1600 * time.Millisecond},
+		{policy: uncapped, attempt: 10, max: 51200 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			actual := c.policy.backoff(c.attempt)
+			if actual < c.max/2 || actual > c.max {
+				t.Errorf("MaxDelay %v: backoff(%d) = %v, expected between %v and %v", c.policy.MaxDelay, c.attempt, actual, c.max/2, c.max)
+			}
+		}
+	}
+
+	// doubling stops short of overflowing
+	if actual := uncapped.backoff(200); actual <= 0 {
+		t.Errorf("backoff(200) = %v, expected a positive delay", actual)
+	}
+}
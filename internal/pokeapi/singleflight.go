@@ -0,0 +1,66 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// flightGroup makes sure only one request per URL is on the wire at a
+// time; callers asking for a URL that is already being fetched wait for
+// that fetch and share its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  []byte
+	err  error
+}
+
+// do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result with shared set.
+// A caller whose ctx ends stops waiting, and if the call it was waiting on
+// was canceled by its own caller it tries again instead of failing.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) (val []byte, shared bool, err error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall)
+		}
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if isContextErr(call.err) && ctx.Err() == nil {
+			continue
+		}
+		return call.val, true, call.err
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.val, false, call.err
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
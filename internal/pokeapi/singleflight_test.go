@@ -0,0 +1,103 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokecache"
+)
+
+func TestConcurrentFetchesShareOneRequest(t *testing.T) {
+	const callers = 20
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// hold the response so the other callers pile up behind it
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	var wg sync.WaitGroup
+	results := make([]PokemonStats, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats, err := client.GetPokemon(context.Background(), "pikachu")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = stats
+		}()
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request for %d callers, got %d", callers, got)
+	}
+	for i, stats := range results {
+		if stats.ID != 25 || stats.Name != "pikachu" {
+			t.Errorf("caller %d got unexpected stats: %+v", i, stats)
+		}
+	}
+}
+
+func TestFlightGroupLeaderCanceled(t *testing.T) {
+	var g flightGroup
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	started := make(chan struct{})
+
+	go g.do(leaderCtx, "key", func() ([]byte, error) {
+		close(started)
+		<-leaderCtx.Done()
+		return nil, leaderCtx.Err()
+	})
+	<-started
+
+	done := make(chan struct{})
+	var val []byte
+	var err error
+	go func() {
+		defer close(done)
+		val, _, err = g.do(context.Background(), "key", func() ([]byte, error) {
+			return []byte("fresh"), nil
+		})
+	}()
+
+	// let the follower start waiting on the leader before canceling it
+	time.Sleep(10 * time.Millisecond)
+	cancelLeader()
+	<-done
+
+	if err != nil || string(val) != "fresh" {
+		t.Errorf("expected follower to fetch on its own, got %q, %v", val, err)
+	}
+}
+
+func TestFetchCountsOneLookup(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/": `{"id": 25, "name": "pikachu"}`,
+	})
+	cache := pokecache.NewCache(time.Hour)
+	t.Cleanup(func() { cache.Close() })
+	client := newTestClient(t, WithBaseURL(srv.URL), WithCache(cache))
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request, got %d", got)
+	}
+	// the miss that led to the request is the only lookup recorded
+	if stats := cache.Stats(); stats.Misses != 1 || stats.Hits != 0 {
+		t.Errorf("expected a single miss, got %+v", stats)
+	}
+}
@@ -0,0 +1,105 @@
+package pokeapi
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultLanguage is the language used by the localized text helpers
+// when none is given
+const DefaultLanguage = "en"
+
+// PokemonSpecies holds the data shared by every form of a pokemon,
+// see https://pokeapi.co/docs/v2#pokemon-species
+type PokemonSpecies struct {
+	ID                   int           `json:"id"`
+	Name                 string        `json:"name"`
+	Order                int           `json:"order"`
+	GenderRate           int           `json:"gender_rate"`
+	CaptureRate          int           `json:"capture_rate"`
+	BaseHappiness        int           `json:"base_happiness"`
+	IsBaby               bool          `json:"is_baby"`
+	IsLegendary          bool          `json:"is_legendary"`
+	IsMythical           bool          `json:"is_mythical"`
+	HatchCounter         int           `json:"hatch_counter"`
+	HasGenderDifferences bool          `json:"has_gender_differences"`
+	GrowthRate           NamedResource `json:"growth_rate"`
+	Habitat              NamedResource `json:"habitat"`
+	Generation           NamedResource `json:"generation"`
+	EvolvesFromSpecies   NamedResource `json:"evolves_from_species"`
+	EvolutionChain       struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Names []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+	FlavorTextEntries []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   NamedResource `json:"language"`
+		Version    NamedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+	Varieties []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   NamedResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// GetPokemonSpecies fetches a pokemon species by name or id. Note that a
+// pokemon and its species don't always share a name (e.g. the pokemon
+// "deoxys-normal" belongs to the species "deoxys"), so use
+// PokemonStats.Species.Name when starting from a pokemon.
+func (c *Client) GetPokemonSpecies(ctx context.Context, nameOrID string) (PokemonSpecies, error) {
+	url := c.endpoint(ResourcePokemonSpecies) + nameOrID + "/"
+	return fetch[PokemonSpecies](ctx, c, url)
+}
+
+// LocalizedName returns the species name in lang, falling back to Name.
+func (s PokemonSpecies) LocalizedName(lang string) string {
+	for _, n := range s.Names {
+		if n.Language.Name == lang {
+			return n.Name
+		}
+	}
+	return s.Name
+}
+
+// Genus returns the species category in lang, e.g. "Mouse Pokémon".
+func (s PokemonSpecies) Genus(lang string) string {
+	for _, g := range s.Genera {
+		if g.Language.Name == lang {
+			return g.Genus
+		}
+	}
+	return ""
+}
+
+// DefaultPokemon returns the name of the species' default pokemon, which
+// isn't always the species name (the species "wormadam" has no pokemon
+// of that name; its default is "wormadam-plant"). Species without
+// varieties fall back to their own name.
+func (s PokemonSpecies) DefaultPokemon() string {
+	for _, v := range s.Varieties {
+		if v.IsDefault {
+			return v.Pokemon.Name
+		}
+	}
+	return s.Name
+}
+
+// FlavorText returns the most recent pokedex entry in lang. The raw
+// entries contain the line and page breaks of the game text box, which
+// are turned into plain spaces.
+func (s PokemonSpecies) FlavorText(lang string) string {
+	for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := s.FlavorTextEntries[i]
+		if entry.Language.Name == lang {
+			return strings.Join(strings.Fields(entry.FlavorText), " ")
+		}
+	}
+	return ""
+}
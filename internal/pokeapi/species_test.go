@@ -0,0 +1,74 @@
+package pokeapi
+
+import (
+	"context"
+	"testing"
+)
+
+const pikachuSpecies = `{
+	"id": 25,
+	"name": "pikachu",
+	"capture_rate": 190,
+	"base_happiness": 50,
+	"is_legendary": false,
+	"is_mythical": false,
+	"growth_rate": {"name": "medium", "url": "https://pokeapi.co/api/v2/growth-rate/2/"},
+	"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"},
+	"names": [
+		{"name": "ピカチュウ", "language": {"name": "ja"}},
+		{"name": "Pikachu", "language": {"name": "en"}}
+	],
+	"genera": [
+		{"genus": "ねずみポケモン", "language": {"name": "ja"}},
+		{"genus": "Mouse Pokémon", "language": {"name": "en"}}
+	],
+	"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "It keeps its tail\nraised to monitor\nits surroundings.", "language": {"name": "en"}, "version": {"name": "gold"}},
+		{"flavor_text": "Lorsque plusieurs\nde ces POKéMON", "language": {"name": "fr"}, "version": {"name": "x"}}
+	],
+	"varieties": [
+		{"is_default": false, "pokemon": {"name": "pikachu-rock-star"}},
+		{"is_default": true, "pokemon": {"name": "pikachu"}}
+	]
+}`
+
+func TestGetPokemonSpecies(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/pokemon-species/pikachu/": pikachuSpecies,
+	})
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	species, err := client.GetPokemonSpecies(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if species.CaptureRate != 190 || species.BaseHappiness != 50 || species.GrowthRate.Name != "medium" {
+		t.Errorf("unexpected species: %+v", species)
+	}
+	if species.EvolutionChain.URL != "https://pokeapi.co/api/v2/evolution-chain/10/" {
+		t.Errorf("unexpected evolution chain: %s", species.EvolutionChain.URL)
+	}
+
+	cases := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{name: "name en", actual: species.LocalizedName("en"), expected: "Pikachu"},
+		{name: "name ja", actual: species.LocalizedName("ja"), expected: "ピカチュウ"},
+		{name: "name fallback", actual: species.LocalizedName("de"), expected: "pikachu"},
+		{name: "genus en", actual: species.Genus("en"), expected: "Mouse Pokémon"},
+		{name: "genus missing", actual: species.Genus("de"), expected: ""},
+		{name: "flavor text latest", actual: species.FlavorText("en"), expected: "It keeps its tail raised to monitor its surroundings."},
+		{name: "flavor text fr", actual: species.FlavorText("fr"), expected: "Lorsque plusieurs de ces POKéMON"},
+		{name: "default pokemon", actual: species.DefaultPokemon(), expected: "pikachu"},
+		{name: "default pokemon fallback", actual: PokemonSpecies{Name: "ditto"}.DefaultPokemon(), expected: "ditto"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("%s: %q != %q", c.name, c.actual, c.expected)
+		}
+	}
+}
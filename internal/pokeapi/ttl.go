@@ -0,0 +1,83 @@
+package pokeapi
+
+import (
+	"strings"
+	"time"
+)
+
+// Forever is the TTL of responses that are cached for as long as the
+// cache keeps them
+const Forever time.Duration = 0
+
+// TTLCache is a Cache that can keep entries for different lengths of
+// time. When a client's cache implements it, responses are cached for the
+// TTL the client's TTLPolicy gives them; pokecache.Cache implements it.
+type TTLCache interface {
+	Cache
+	AddWithTTL(key string, val []byte, ttl time.Duration)
+}
+
+// TTLPolicy decides how long responses are cached depending on the kind
+// of resource they hold
+type TTLPolicy struct {
+	// Resources holds the TTL of single resources by kind, such as
+	// ResourcePokemon
+	Resources map[string]time.Duration
+	// Lists is the TTL of pages of any list endpoint
+	Lists time.Duration
+	// Default is the TTL of resources missing from Resources
+	Default time.Duration
+}
+
+// DefaultTTLPolicy keeps game data, which doesn't change between
+// requests, for as long as the cache allows; list pages shift whenever
+// PokeAPI adds something, so they are refreshed more often
+var DefaultTTLPolicy = TTLPolicy{
+	Resources: map[string]time.Duration{
+		ResourcePokemon:        Forever,
+		ResourcePokemonSpecies: Forever,
+		ResourceMove:           Forever,
+		ResourceType:           Forever,
+		ResourceItem:           Forever,
+		ResourceGrowthRate:     Forever,
+		ResourceEvolutionChain: Forever,
+		ResourceLocationArea:   24 * time.Hour,
+	},
+	Lists:   10 * time.Minute,
+	Default: time.Hour,
+}
+
+// WithTTLPolicy sets how long responses are cached. It only has an
+// effect if the client's cache is a TTLCache.
+func WithTTLPolicy(policy TTLPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.ttl = &policy
+	}
+}
+
+// ttlFor returns how long the response of url is cached
+func (c *Client) ttlFor(url string) time.Duration {
+	path, ok := strings.CutPrefix(url, c.baseURL)
+	if !ok {
+		return c.ttl.Default
+	}
+	// list pages are the bare endpoint, e.g. pokemon/?offset=20
+	path, _, _ = strings.Cut(path, "?")
+	resource, rest, _ := strings.Cut(path, "/")
+	if rest == "" {
+		return c.ttl.Lists
+	}
+	if ttl, ok := c.ttl.Resources[resource]; ok {
+		return ttl
+	}
+	return c.ttl.Default
+}
+
+// cacheAdd stores a response body with the TTL of its resource kind
+func (c *Client) cacheAdd(url string, body []byte) {
+	if cache, ok := c.cache.(TTLCache); ok {
+		cache.AddWithTTL(url, body, c.ttlFor(url))
+		return
+	}
+	c.cache.Add(url, body)
+}
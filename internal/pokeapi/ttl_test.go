@@ -0,0 +1,88 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ttlRecorder is a TTLCache that never hits and remembers the TTL each
+// key was added with
+type ttlRecorder struct {
+	mu   sync.Mutex
+	ttls map[string]time.Duration
+}
+
+func (r *ttlRecorder) Add(key string, val []byte) {
+	panic("Add called on a TTLCache")
+}
+
+func (r *ttlRecorder) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttls[key] = ttl
+}
+
+func (r *ttlRecorder) Get(key string) ([]byte, bool) {
+	return nil, false
+}
+
+func TestTTLPolicy(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/":             `{"id": 25, "name": "pikachu"}`,
+		"/pokemon/":                     `{"count": 0, "results": []}`,
+		"/location-area/canalave-city/": `{"name": "canalave-city"}`,
+		"/growth-rate/medium/":          `{"name": "medium"}`,
+	})
+	recorder := &ttlRecorder{ttls: make(map[string]time.Duration)}
+	policy := TTLPolicy{
+		Resources: map[string]time.Duration{
+			ResourcePokemon:      Forever,
+			ResourceLocationArea: 24 * time.Hour,
+		},
+		Lists:   time.Minute,
+		Default: time.Hour,
+	}
+	client := newTestClient(t, WithBaseURL(srv.URL), WithCache(recorder), WithTTLPolicy(policy))
+	ctx := context.Background()
+
+	if _, err := client.GetPokemon(ctx, "pikachu"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListPage(ctx, ResourcePokemon, 20, 40); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetLocationArea(ctx, "canalave-city"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetGrowthRate(ctx, "medium"); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		url      string
+		expected time.Duration
+	}{
+		{url: srv.URL + "/pokemon/pikachu/", expected: Forever},
+		{url: srv.URL + "/pokemon/?offset=40&limit=20", expected: time.Minute},
+		{url: srv.URL + "/location-area/canalave-city/", expected: 24 * time.Hour},
+		// not in the policy
+		{url: srv.URL + "/growth-rate/medium/", expected: time.Hour},
+	}
+	for _, c := range cases {
+		ttl, ok := recorder.ttls[c.url]
+		if !ok {
+			t.Errorf("%s was not cached", c.url)
+			continue
+		}
+		if ttl != c.expected {
+			t.Errorf("%s was cached for %v, expected %v", c.url, ttl, c.expected)
+		}
+	}
+
+	// a url outside the client's base url gets the default
+	if ttl := client.ttlFor("https://pokeapi.co/api/v2/pokemon/pikachu/"); ttl != time.Hour {
+		t.Errorf("expected the default ttl for a foreign url, got %v", ttl)
+	}
+}
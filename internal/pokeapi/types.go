@@ -0,0 +1,128 @@
+package pokeapi
+
+import (
+	"context"
+	"slices"
+)
+
+// Type is an elemental type and how it fares against the others,
+// see https://pokeapi.co/docs/v2#types
+type Type struct {
+	ID              int             `json:"id"`
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+}
+
+// DamageRelations lists the types this type deals double, half or no
+// damage to, and takes double, half or no damage from
+type DamageRelations struct {
+	DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedResource `json:"half_damage_to"`
+	NoDamageTo       []NamedResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedResource `json:"no_damage_from"`
+}
+
+// empty reports whether the type has no relations at all, like the
+// "unknown" and "shadow" types
+func (r DamageRelations) empty() bool {
+	return len(r.DoubleDamageTo)+len(r.HalfDamageTo)+len(r.NoDamageTo)+
+		len(r.DoubleDamageFrom)+len(r.HalfDamageFrom)+len(r.NoDamageFrom) == 0
+}
+
+// GetType fetches a type by name or id, e.g. "electric"
+func (c *Client) GetType(ctx context.Context, nameOrID string) (Type, error) {
+	url := c.endpoint(ResourceType) + nameOrID + "/"
+	return fetch[Type](ctx, c, url)
+}
+
+// TypeChart holds the damage multiplier of every attacking type against
+// every defending type. Build one with NewTypeChart or GetTypeChart.
+type TypeChart struct {
+	types      []string
+	multiplier map[[2]string]float64
+}
+
+// NewTypeChart builds a chart from the damage relations of types. Types
+// without any relations are left out.
+func NewTypeChart(types []Type) TypeChart {
+	chart := TypeChart{multiplier: make(map[[2]string]float64)}
+	for _, t := range types {
+		rel := t.DamageRelations
+		if rel.empty() {
+			continue
+		}
+		chart.types = append(chart.types, t.Name)
+
+		for _, to := range rel.DoubleDamageTo {
+			chart.multiplier[[2]string{t.Name, to.Name}] = 2
+		}
+		for _, to := range rel.HalfDamageTo {
+			chart.multiplier[[2]string{t.Name, to.Name}] = 0.5
+		}
+		for _, to := range rel.NoDamageTo {
+			chart.multiplier[[2]string{t.Name, to.Name}] = 0
+		}
+	}
+	slices.Sort(chart.types)
+	return chart
+}
+
+// GetTypeChart fetches every type and builds a chart from them
+func (c *Client) GetTypeChart(ctx context.Context) (TypeChart, error) {
+	var types []Type
+	for res, err := range c.List(ctx, ResourceType, 0) {
+		if err != nil {
+			return TypeChart{}, err
+		}
+		t, err := c.GetType(ctx, res.Name)
+		if err != nil {
+			return TypeChart{}, err
+		}
+		types = append(types, t)
+	}
+	return NewTypeChart(types), nil
+}
+
+// Types returns the names of the types in the chart, sorted
+func (c TypeChart) Types() []string {
+	return slices.Clone(c.types)
+}
+
+// Has reports whether the chart knows a type
+func (c TypeChart) Has(name string) bool {
+	_, found := slices.BinarySearch(c.types, name)
+	return found
+}
+
+// Multiplier returns how effective an attacking type is against a single
+// defending type: 0, 0.5, 1 or 2
+func (c TypeChart) Multiplier(attacking, defending string) float64 {
+	if m, ok := c.multiplier[[2]string{attacking, defending}]; ok {
+		return m
+	}
+	return 1
+}
+
+// Effectiveness returns the combined multiplier of an attacking type
+// against a single or dual type, e.g. 4 for electric against water/flying
+func (c TypeChart) Effectiveness(attacking string, defending ...string) float64 {
+	eff := 1.0
+	for _, t := range defending {
+		eff *= c.Multiplier(attacking, t)
+	}
+	return eff
+}
+
+// Against returns the multiplier of every attacking type in the chart
+// against a single or dual type, leaving out normally effective ones
+func (c TypeChart) Against(defending ...string) map[string]float64 {
+	against := make(map[string]float64)
+	for _, attacking := range c.types {
+		if eff := c.Effectiveness(attacking, defending...); eff != 1 {
+			against[attacking] = eff
+		}
+	}
+	return against
+}
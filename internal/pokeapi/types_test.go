@@ -0,0 +1,85 @@
+package pokeapi
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// a small slice of the real chart: the relations of a few types
+// against each other
+var testTypes = map[string]string{
+	"/type/": `{"count": 5, "next": null, "results": [
+		{"name": "electric"}, {"name": "water"}, {"name": "ground"}, {"name": "flying"}, {"name": "unknown"}
+	]}`,
+	"/type/electric/": `{"id": 13, "name": "electric", "damage_relations": {
+		"double_damage_to": [{"name": "water"}, {"name": "flying"}],
+		"half_damage_to": [{"name": "electric"}, {"name": "grass"}, {"name": "dragon"}],
+		"no_damage_to": [{"name": "ground"}],
+		"double_damage_from": [{"name": "ground"}],
+		"half_damage_from": [{"name": "electric"}, {"name": "flying"}, {"name": "steel"}],
+		"no_damage_from": []
+	}}`,
+	"/type/water/": `{"id": 11, "name": "water", "damage_relations": {
+		"double_damage_to": [{"name": "ground"}, {"name": "rock"}, {"name": "fire"}],
+		"half_damage_to": [{"name": "water"}, {"name": "grass"}, {"name": "dragon"}],
+		"double_damage_from": [{"name": "grass"}, {"name": "electric"}],
+		"half_damage_from": [{"name": "steel"}, {"name": "fire"}, {"name": "water"}, {"name": "ice"}]
+	}}`,
+	"/type/ground/": `{"id": 5, "name": "ground", "damage_relations": {
+		"double_damage_to": [{"name": "electric"}],
+		"no_damage_to": [{"name": "flying"}],
+		"double_damage_from": [{"name": "water"}],
+		"no_damage_from": [{"name": "electric"}]
+	}}`,
+	"/type/flying/": `{"id": 3, "name": "flying", "damage_relations": {
+		"half_damage_to": [{"name": "electric"}],
+		"double_damage_from": [{"name": "electric"}],
+		"no_damage_from": [{"name": "ground"}]
+	}}`,
+	"/type/unknown/": `{"id": 10001, "name": "unknown", "damage_relations": {}}`,
+}
+
+func TestGetTypeChart(t *testing.T) {
+	srv, _ := newTestServer(t, testTypes)
+	client := newTestClient(t, WithBaseURL(srv.URL))
+
+	chart, err := client.GetTypeChart(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// unknown has no relations and is left out
+	if types := chart.Types(); !slices.Equal(types, []string{"electric", "flying", "ground", "water"}) {
+		t.Errorf("unexpected types: %v", types)
+	}
+	if !chart.Has("ground") || chart.Has("unknown") {
+		t.Errorf("unexpected Has results")
+	}
+
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{attacking: "electric", defending: []string{"water"}, expected: 2},
+		{attacking: "electric", defending: []string{"water", "flying"}, expected: 4},
+		{attacking: "electric", defending: []string{"water", "ground"}, expected: 0},
+		{attacking: "electric", defending: []string{"electric"}, expected: 0.5},
+		{attacking: "water", defending: []string{"water", "dragon"}, expected: 0.25},
+		{attacking: "ground", defending: []string{"water"}, expected: 1},
+		{attacking: "water", defending: []string{"fire"}, expected: 2},
+	}
+	for _, c := range cases {
+		if actual := chart.Effectiveness(c.attacking, c.defending...); actual != c.expected {
+			t.Errorf("%s against %v = %v, expected %v", c.attacking, c.defending, actual, c.expected)
+		}
+	}
+
+	against := chart.Against("water", "flying")
+	expected := map[string]float64{"electric": 4, "ground": 0, "water": 0.5}
+	if !maps.Equal(against, expected) {
+		t.Errorf("expected %v against water/flying, got %v", expected, against)
+	}
+}
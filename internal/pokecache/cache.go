@@ -1,52 +1,213 @@
 package pokecache
 
 import (
+	"container/list"
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var cacheDebug bool = false
-var cacheDelDebug bool = true
+
+// deletions show up in Stats, so they are no longer printed by default
+var cacheDelDebug bool = false
+
+// Stats describe how a cache has been used since it was created
+type Stats struct {
+	Hits   int
+	Misses int
+	// Evictions are entries dropped to stay within the size limits,
+	// Expirations ones dropped because their ttl ran out
+	Evictions   int
+	Expirations int
+	// Entries and Bytes are what the cache holds right now
+	Entries int
+	Bytes   int
+}
+
+// HitRate is the share of lookups that were hits, 0 to 1
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
 
 type cacheEntry struct {
+	key       string
 	createdAt time.Time
+	// expiresAt is zero for entries that never expire
+	expiresAt time.Time
 	val       []byte
 }
 
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// size is what an entry counts against the byte budget
+func (e *cacheEntry) size() int {
+	return len(e.key) + len(e.val)
+}
+
 type Cache struct {
-	cacheData map[string]cacheEntry
-	mu        *sync.Mutex
+	cacheData map[string]*list.Element
+	// lru holds the entries from most to least recently used
+	lru *list.List
+	mu  *sync.Mutex
+
+	// ttl is how long entries added with Add live
+	ttl time.Duration
+	// sweepInterval is how often expired entries are dropped
+	sweepInterval time.Duration
+
+	// closing stop ends the reapLoop, which closes done on its way out
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce *sync.Once
+
+	// limits on the cache size, 0 means no limit
+	maxEntries int
+	maxBytes   int
+	bytes      int
+
+	stats Stats
+}
+
+// Option configures a Cache created with NewCache
+type Option func(*Cache)
+
+// WithMaxEntries limits the number of entries; adding more evicts the
+// least recently used ones
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
 }
 
-// creates new Cache and launches the reapLoop as a go routine
-func NewCache(interval time.Duration) Cache {
+// WithMaxBytes limits the total size of keys and values; adding more
+// evicts the least recently used entries. A value bigger than the whole
+// budget is not cached at all.
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithSweepInterval sets how often expired entries are dropped. By
+// default it is the cache's ttl, or DefaultSweepInterval when entries
+// don't expire. Expired entries are never returned by Get either way.
+func WithSweepInterval(interval time.Duration) Option {
+	return func(c *Cache) {
+		c.sweepInterval = interval
+	}
+}
+
+// DefaultSweepInterval is how often a cache whose entries don't expire
+// by default looks for ones added with a ttl
+const DefaultSweepInterval = time.Minute
+
+// creates new Cache and launches the reapLoop as a go routine; entries
+// added with Add live for ttl, or forever if ttl is 0 or less. Call
+// Close to stop the reapLoop once the cache is no longer needed.
+func NewCache(ttl time.Duration, opts ...Option) *Cache {
+	return NewCacheContext(context.Background(), ttl, opts...)
+}
+
+// NewCacheContext is like NewCache, but the reapLoop also stops when ctx
+// is done
+func NewCacheContext(ctx context.Context, ttl time.Duration, opts ...Option) *Cache {
 	if cacheDebug {
-		fmt.Println("CACHE: Creating new cache with interval: ", interval)
+		fmt.Println("CACHE: Creating new cache with ttl: ", ttl)
 	}
 
-	c := Cache{
-		cacheData: make(map[string]cacheEntry),
+	c := &Cache{
+		cacheData: make(map[string]*list.Element),
+		lru:       list.New(),
 		mu:        &sync.Mutex{},
+		ttl:       ttl,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		closeOnce: &sync.Once{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.sweepInterval <= 0 {
+		c.sweepInterval = ttl
+		if ttl <= 0 {
+			c.sweepInterval = DefaultSweepInterval
+		}
 	}
-	go c.reapLoop(interval)
+	// counted before it starts so Reapers never misses one that hasn't
+	// been scheduled yet
+	running.Add(1)
+	go c.reapLoop(ctx, c.sweepInterval)
 
 	return c
 }
 
-func (c Cache) Add(key string, val []byte) {
+// running counts the reapLoops that haven't exited yet
+var running atomic.Int64
+
+// Reapers returns how many caches still have a reapLoop running, so tests
+// can check that every cache they created was closed.
+func Reapers() int {
+	return int(running.Load())
+}
+
+// Close stops the reapLoop and waits for it to exit. The cache keeps
+// working afterwards, but expired entries are only dropped when Get
+// comes across them. Close can be called more than once.
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	<-c.done
+	return nil
+}
+
+// Add caches val under key for the cache's default ttl
+func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.ttl)
+}
+
+// AddWithTTL caches val under key for ttl, or forever if ttl is 0 or
+// less. Entries can still be evicted to stay within the cache's limits.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
 	if cacheDebug {
 		fmt.Println("CACHE: Adding item to cache with key: ", key)
-		fmt.Println("CACHE: len(val): ", len(val))
+		fmt.Println("CACHE: len(val): ", len(val), ", ttl: ", ttl)
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.cacheData[key] = cacheEntry{
-		createdAt: time.Now(),
+	now := time.Now()
+	entry := &cacheEntry{
+		key:       key,
+		createdAt: now,
 		val:       val,
 	}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	if elem, ok := c.cacheData[key]; ok {
+		c.remove(elem)
+	}
+	if c.maxBytes > 0 && entry.size() > c.maxBytes {
+		if cacheDebug {
+			fmt.Println("CACHE: Item is larger than the cache, not adding key: ", key)
+		}
+		return
+	}
+
+	c.cacheData[key] = c.lru.PushFront(entry)
+	c.bytes += entry.size()
+	c.evict()
 
 	if cacheDebug {
 		fmt.Println("CACHE: Added item to cache with key: ", key)
@@ -54,7 +215,7 @@ func (c Cache) Add(key string, val []byte) {
 	}
 }
 
-func (c Cache) Get(key string) ([]byte, bool) {
+func (c *Cache) Get(key string) ([]byte, bool) {
 	if cacheDebug {
 		fmt.Println("CACHE: Looking in cache for item with key: ", key)
 	}
@@ -62,14 +223,27 @@ func (c Cache) Get(key string) ([]byte, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	val, ok := c.cacheData[key]
+	elem, ok := c.cacheData[key]
 	if !ok {
 		if cacheDebug {
 			fmt.Println("CACHE: Did not find item in cache with key: ", key)
 		}
 
+		c.stats.Misses++
 		return []byte{}, false
 	}
+	val := elem.Value.(*cacheEntry)
+	if val.expired(time.Now()) {
+		if cacheDebug || cacheDelDebug {
+			fmt.Println("CACHE: Deleting from cache item with key: ", key)
+		}
+		c.remove(elem)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return []byte{}, false
+	}
+	c.lru.MoveToFront(elem)
+	c.stats.Hits++
 
 	if cacheDebug {
 		fmt.Println("CACHE: Found item in cache with key: ", key)
@@ -79,23 +253,111 @@ func (c Cache) Get(key string) ([]byte, bool) {
 	return val.val, true
 }
 
+// Stats returns the cache's counters and current size
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	stats.Bytes = c.bytes
+	return stats
+}
+
+// Keys returns every cached key, most recently used first. Entries that
+// expired but weren't dropped yet are left out.
+func (c *Cache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	keys := make([]string, 0, c.lru.Len())
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if entry := elem.Value.(*cacheEntry); !entry.expired(now) {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// Remove deletes the entry cached under key and reports whether there
+// was one
+func (c *Cache) Remove(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.cacheData[key]
+	if ok {
+		c.remove(elem)
+	}
+	return ok
+}
+
+// Clear deletes every entry. The counters are kept.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cacheData = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
+}
+
+// evict drops least recently used entries until the cache is within its
+// limits; c.mu must be held
+func (c *Cache) evict() {
+	for c.lru.Len() > 0 && c.overLimit() {
+		oldest := c.lru.Back()
+		if cacheDebug || cacheDelDebug {
+			fmt.Println("CACHE: Evicting from cache item with key: ", oldest.Value.(*cacheEntry).key)
+		}
+		c.remove(oldest)
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) overLimit() bool {
+	return (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+// remove deletes an entry; c.mu must be held
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.cacheData, entry.key)
+	c.bytes -= entry.size()
+}
+
 // blocks on the passed ticker channel until time data
-// is sent at the interval specified at Cache creation
-func (c Cache) reapLoop(interval time.Duration) {
+// is sent at the sweep interval, then drops expired entries;
+// returns once the cache is closed or ctx is done
+func (c *Cache) reapLoop(ctx context.Context, interval time.Duration) {
+	defer close(c.done)
+	// uncounted before done is closed, so Close returning means the
+	// count dropped
+	defer running.Add(-1)
 	reapTicker := time.NewTicker(interval)
-	for ; true; <-reapTicker.C {
+	defer reapTicker.Stop()
+
+	for {
 		if cacheDebug {
 			fmt.Println("CACHE: reapLoop is executing, time: ", time.Now())
 		}
 		c.mu.Lock()
-		for key, entry := range c.cacheData {
-			if time.Since(entry.createdAt) > interval {
+		now := time.Now()
+		for key, elem := range c.cacheData {
+			if elem.Value.(*cacheEntry).expired(now) {
 				if cacheDebug || cacheDelDebug {
 					fmt.Println("CACHE: Deleting from cache item with key: ", key)
 				}
-				delete(c.cacheData, key)
+				c.remove(elem)
+				c.stats.Expirations++
 			}
 		}
 		c.mu.Unlock()
+
+		select {
+		case <-reapTicker.C:
+		case <-c.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
 	}
 }
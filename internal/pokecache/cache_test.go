@@ -25,6 +25,7 @@ func TestAddGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 			cache.Add(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -43,6 +44,7 @@ func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = baseTime + 5*time.Millisecond
 	cache := NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -59,3 +61,155 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+// keys returns the cached keys from most to least recently used
+func keys(c *Cache) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []string
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*cacheEntry).key)
+	}
+	return keys
+}
+
+func TestMaxEntries(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxEntries(2))
+	defer cache.Close()
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+
+	// using a makes b the least recently used
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("expected to find a")
+	}
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if got := fmt.Sprint(keys(cache)); got != "[c a]" {
+		t.Errorf("expected [c a] to be cached, got %s", got)
+	}
+
+	// replacing a key does not evict anything
+	cache.Add("a", []byte("4"))
+	if val, _ := cache.Get("a"); string(val) != "4" || len(keys(cache)) != 2 {
+		t.Errorf("expected a to be replaced, got %q and %v", val, keys(cache))
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	const budget = 1000
+	cache := NewCache(time.Minute, WithMaxBytes(budget))
+	defer cache.Close()
+
+	// a stream of distinct keys far bigger than the budget
+	val := make([]byte, 90)
+	for i := 0; i < 10000; i++ {
+		cache.Add(fmt.Sprintf("https://example.com/%d", i), val)
+
+		cache.mu.Lock()
+		bytes, entries := cache.bytes, len(cache.cacheData)
+		cache.mu.Unlock()
+		if bytes > budget {
+			t.Fatalf("after %d adds the cache holds %d bytes, over its %d budget", i+1, bytes, budget)
+		}
+		if entries != cache.lru.Len() {
+			t.Fatalf("map and lru list disagree: %d vs %d entries", entries, cache.lru.Len())
+		}
+	}
+
+	// the newest entries are the ones kept
+	if _, ok := cache.Get("https://example.com/9999"); !ok {
+		t.Errorf("expected the newest entry to be cached")
+	}
+	if _, ok := cache.Get("https://example.com/0"); ok {
+		t.Errorf("expected the oldest entry to be evicted")
+	}
+
+	// a value bigger than the whole budget is not cached
+	cache.Add("huge", make([]byte, budget+1))
+	if _, ok := cache.Get("huge"); ok {
+		t.Errorf("expected an oversized value not to be cached")
+	}
+}
+
+func TestAddWithTTL(t *testing.T) {
+	// the sweep is far off, so only Get's own expiry check can drop
+	// the short lived entry
+	cache := NewCache(time.Hour, WithSweepInterval(time.Hour))
+	defer cache.Close()
+	cache.AddWithTTL("short", []byte("1"), 5*time.Millisecond)
+	cache.AddWithTTL("forever", []byte("2"), 0)
+	cache.Add("default", []byte("3"))
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected the short lived entry to expire")
+	}
+	if _, ok := cache.Get("forever"); !ok {
+		t.Errorf("expected the entry without a ttl to be cached")
+	}
+	if _, ok := cache.Get("default"); !ok {
+		t.Errorf("expected the entry with the default ttl to be cached")
+	}
+	if len(keys(cache)) != 2 {
+		t.Errorf("expected the expired entry to be removed, got %v", keys(cache))
+	}
+}
+
+func TestSweepInterval(t *testing.T) {
+	// entries live forever by default, but the sweep still drops
+	// the ones given a ttl
+	cache := NewCache(0, WithSweepInterval(5*time.Millisecond))
+	defer cache.Close()
+	cache.AddWithTTL("short", []byte("1"), time.Millisecond)
+	cache.Add("forever", []byte("2"))
+
+	time.Sleep(20 * time.Millisecond)
+
+	if got := keys(cache); len(got) != 1 || got[0] != "forever" {
+		t.Errorf("expected the sweep to leave only the entry without a ttl, got %v", got)
+	}
+}
+
+func TestStats(t *testing.T) {
+	cache := NewCache(time.Hour, WithMaxEntries(2))
+	defer cache.Close()
+
+	cache.Add("a", []byte("12345"))
+	cache.AddWithTTL("short", []byte("1"), 5*time.Millisecond)
+	cache.Get("a")
+	cache.Get("missing")
+	time.Sleep(10 * time.Millisecond)
+	// an expired entry found on lookup is both a miss and an expiration
+	cache.Get("short")
+	cache.Add("b", []byte("123"))
+	cache.Add("c", []byte("12"))
+
+	// sizes count the key as well as the value
+	expected := Stats{Hits: 1, Misses: 2, Evictions: 1, Expirations: 1, Entries: 2, Bytes: 7}
+	if actual := cache.Stats(); actual != expected {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+	if rate := cache.Stats().HitRate(); rate != 1.0/3 {
+		t.Errorf("expected a hit rate of 1/3, got %v", rate)
+	}
+	if actual := cache.Keys(); fmt.Sprint(actual) != "[c b]" {
+		t.Errorf("expected keys [c b], got %v", actual)
+	}
+
+	if !cache.Remove("b") || cache.Remove("b") {
+		t.Errorf("expected Remove to report b only once")
+	}
+	cache.Clear()
+	stats := cache.Stats()
+	if stats.Entries != 0 || stats.Bytes != 0 || len(cache.Keys()) != 0 {
+		t.Errorf("expected an empty cache after Clear, got %+v", stats)
+	}
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("expected Clear to keep the counters, got %+v", stats)
+	}
+}
@@ -0,0 +1,487 @@
+package pokecache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+var errNotCached = errors.New("pokecache: not cached")
+
+// diskIndexVersion is bumped when the index format changes; an index of
+// another version is thrown away like a corrupt one
+const diskIndexVersion = 1
+
+// DiskCache keeps entries as files in a directory so they survive
+// restarts. It has the same Get/Add API as Cache and is safe to share
+// between processes: a lock file guards the index of entries.
+//
+// The directory holds index.json, a lock file and one content file per
+// entry under entries/. Every file is replaced atomically, and an entry
+// whose content doesn't match the size and checksum in the index is
+// treated as missing, so a crash mid-write costs at most that entry.
+type DiskCache struct {
+	dir  string
+	ttl  time.Duration
+	gzip bool
+	// maxEntries and maxBytes are 0 when unlimited; Bytes counts the
+	// content files as stored
+	maxEntries int
+	maxBytes   int
+
+	// mu guards index, the last index read from disk; it is reloaded
+	// whenever another process replaced index.json
+	mu        sync.Mutex
+	index     diskIndex
+	indexStat os.FileInfo
+	// stats counts what this process saw only
+	stats Stats
+}
+
+type diskIndex struct {
+	Version int                  `json:"version"`
+	Entries map[string]diskEntry `json:"entries"`
+}
+
+type diskEntry struct {
+	// File is the name of the content file under entries/
+	File      string    `json:"file"`
+	CreatedAt time.Time `json:"created_at"`
+	// TTL is 0 for entries that never expire
+	TTL time.Duration `json:"ttl"`
+	// Size and SHA256 describe the content file as stored
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+	Gzip   bool   `json:"gzip"`
+}
+
+func (e diskEntry) expired(now time.Time) bool {
+	return e.TTL > 0 && now.After(e.CreatedAt.Add(e.TTL))
+}
+
+// same reports whether e and other describe the same write. Entries
+// can't be compared with ==: a CreatedAt read back from JSON has lost its
+// monotonic reading and may have another *time.Location.
+func (e diskEntry) same(other diskEntry) bool {
+	return e.File == other.File && e.SHA256 == other.SHA256 && e.CreatedAt.Equal(other.CreatedAt)
+}
+
+// DiskOption configures a DiskCache created with NewDiskCache
+type DiskOption func(*DiskCache)
+
+// WithGzip compresses content files. Entries written either way can be
+// read back.
+func WithGzip() DiskOption {
+	return func(c *DiskCache) {
+		c.gzip = true
+	}
+}
+
+// WithDiskMaxEntries caps the number of entries; the oldest are
+// dropped first. 0 means no limit.
+func WithDiskMaxEntries(n int) DiskOption {
+	return func(c *DiskCache) {
+		c.maxEntries = n
+	}
+}
+
+// WithDiskMaxBytes caps the total size of the content files; the oldest
+// entries are dropped first. 0 means no limit.
+func WithDiskMaxBytes(n int) DiskOption {
+	return func(c *DiskCache) {
+		c.maxBytes = n
+	}
+}
+
+// DefaultDir returns the directory the PokeAPI cache lives in by default,
+// following the XDG base directory spec
+func DefaultDir() (string, error) {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); filepath.IsAbs(cacheHome) {
+		return filepath.Join(cacheHome, "pokedex"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("pokecache: can't find a cache directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "pokedex"), nil
+}
+
+// NewDiskCache opens or creates a cache in dir. Entries added with Add
+// live for ttl, or forever if ttl is 0 or less.
+func NewDiskCache(dir string, ttl time.Duration, opts ...DiskOption) (*DiskCache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "entries"), 0o755); err != nil {
+		return nil, fmt.Errorf("pokecache: failed to create %s: %w", dir, err)
+	}
+	c := &DiskCache{
+		dir: dir,
+		ttl: ttl,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Dir returns the directory the cache is stored in
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+func (c *DiskCache) indexPath() string {
+	return filepath.Join(c.dir, "index.json")
+}
+
+func (c *DiskCache) lockPath() string {
+	return filepath.Join(c.dir, "lock")
+}
+
+func (c *DiskCache) entryPath(file string) string {
+	return filepath.Join(c.dir, "entries", file)
+}
+
+// Add caches val under key for the cache's default ttl
+func (c *DiskCache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.ttl)
+}
+
+// AddWithTTL caches val under key for ttl, or forever if ttl is 0 or
+// less. Failing to write is not an error for a cache: the entry is
+// just not there next time.
+func (c *DiskCache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	stored := val
+	if c.gzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(val)
+		if err := zw.Close(); err != nil {
+			return
+		}
+		stored = buf.Bytes()
+	}
+	sum := sha256.Sum256(stored)
+	keySum := sha256.Sum256([]byte(key))
+	entry := diskEntry{
+		File:      hex.EncodeToString(keySum[:]),
+		CreatedAt: time.Now(),
+		TTL:       max(ttl, 0),
+		Size:      len(stored),
+		SHA256:    hex.EncodeToString(sum[:]),
+		Gzip:      c.gzip,
+	}
+
+	c.update(func(index *diskIndex) bool {
+		if err := writeFileAtomic(c.entryPath(entry.File), stored); err != nil {
+			return false
+		}
+		index.Entries[key] = entry
+		return true
+	})
+}
+
+// Get returns the value cached under key, if it is there, not expired
+// and intact
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	val, _, ok := c.get(key)
+	return val, ok
+}
+
+// get also returns when the entry expires, zero if it never does
+func (c *DiskCache) get(key string) ([]byte, time.Time, bool) {
+	entry, val, intact, err := c.lookup(key)
+	if err != nil {
+		c.count(func(s *Stats) { s.Misses++ })
+		return nil, time.Time{}, false
+	}
+	if !intact || entry.expired(time.Now()) {
+		c.drop(key, entry)
+		c.count(func(s *Stats) {
+			s.Misses++
+			if intact {
+				s.Expirations++
+			}
+		})
+		return nil, time.Time{}, false
+	}
+	c.count(func(s *Stats) { s.Hits++ })
+
+	var expiresAt time.Time
+	if entry.TTL > 0 {
+		expiresAt = entry.CreatedAt.Add(entry.TTL)
+	}
+	return val, expiresAt, true
+}
+
+// Remove deletes the entry cached under key and reports whether there
+// was one
+func (c *DiskCache) Remove(key string) bool {
+	removed := false
+	c.update(func(index *diskIndex) bool {
+		entry, ok := index.Entries[key]
+		if !ok {
+			return false
+		}
+		delete(index.Entries, key)
+		os.Remove(c.entryPath(entry.File))
+		removed = true
+		return true
+	})
+	return removed
+}
+
+// Clear deletes every entry
+func (c *DiskCache) Clear() {
+	c.update(func(index *diskIndex) bool {
+		for key, entry := range index.Entries {
+			delete(index.Entries, key)
+			os.Remove(c.entryPath(entry.File))
+		}
+		return true
+	})
+}
+
+// Keys returns every cached key, sorted
+func (c *DiskCache) Keys() []string {
+	var keys []string
+	c.view(func(index diskIndex) {
+		now := time.Now()
+		for key, entry := range index.Entries {
+			if !entry.expired(now) {
+				keys = append(keys, key)
+			}
+		}
+	})
+	slices.Sort(keys)
+	return keys
+}
+
+// Stats returns what the cache holds on disk, with the hits, misses,
+// expirations and evictions seen by this process. Bytes is the size of the content
+// files as stored, so after compression when gzip is on.
+func (c *DiskCache) Stats() Stats {
+	var stats Stats
+	c.view(func(index diskIndex) {
+		stats = c.stats
+		now := time.Now()
+		for _, entry := range index.Entries {
+			if entry.expired(now) {
+				continue
+			}
+			stats.Entries++
+			stats.Bytes += entry.Size
+		}
+	})
+	return stats
+}
+
+// count updates the lookup counters
+func (c *DiskCache) count(update func(*Stats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	update(&c.stats)
+}
+
+// view reads the current index under a shared lock
+func (c *DiskCache) view(read func(diskIndex)) {
+	unlock, err := lockFile(c.lockPath(), false)
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadIndex()
+	read(c.index)
+}
+
+// drop deletes a bad entry, unless another writer replaced it since it
+// was read
+func (c *DiskCache) drop(key string, bad diskEntry) {
+	c.update(func(index *diskIndex) bool {
+		if current, ok := index.Entries[key]; !ok || !current.same(bad) {
+			return false
+		}
+		delete(index.Entries, key)
+		os.Remove(c.entryPath(bad.File))
+		return true
+	})
+}
+
+// lookup finds the index entry of key and reads its content under a
+// shared lock. A missing key is errNotCached; intact is false for an
+// entry whose content file is missing or corrupt.
+func (c *DiskCache) lookup(key string) (entry diskEntry, val []byte, intact bool, err error) {
+	unlock, err := lockFile(c.lockPath(), false)
+	if err != nil {
+		return diskEntry{}, nil, false, err
+	}
+	defer unlock()
+
+	c.mu.Lock()
+	c.loadIndex()
+	entry, ok := c.index.Entries[key]
+	c.mu.Unlock()
+	if !ok {
+		return diskEntry{}, nil, false, errNotCached
+	}
+
+	val, err = c.read(entry)
+	return entry, val, err == nil, nil
+}
+
+// update changes the index under an exclusive lock, writing it back if
+// change reports that it changed something. Expired entries are dropped
+// along the way.
+func (c *DiskCache) update(change func(*diskIndex) bool) {
+	unlock, err := lockFile(c.lockPath(), true)
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadIndex()
+
+	changed := change(&c.index)
+	now := time.Now()
+	for key, entry := range c.index.Entries {
+		if entry.expired(now) {
+			delete(c.index.Entries, key)
+			os.Remove(c.entryPath(entry.File))
+			changed = true
+		}
+	}
+	if c.prune() {
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	data, err := json.Marshal(c.index)
+	if err != nil {
+		return
+	}
+	if err := writeFileAtomic(c.indexPath(), data); err != nil {
+		return
+	}
+	c.indexStat, _ = os.Stat(c.indexPath())
+}
+
+// prune drops the oldest entries until the index is within the size
+// limits and reports whether it dropped any. The disk tier doesn't track
+// reads, so unlike Cache this is oldest first rather than least recently
+// used. c.mu and the lock file must be held.
+func (c *DiskCache) prune() bool {
+	if c.maxEntries <= 0 && c.maxBytes <= 0 {
+		return false
+	}
+	total := 0
+	keys := make([]string, 0, len(c.index.Entries))
+	for key, entry := range c.index.Entries {
+		keys = append(keys, key)
+		total += entry.Size
+	}
+	over := func() bool {
+		return (c.maxEntries > 0 && len(c.index.Entries) > c.maxEntries) ||
+			(c.maxBytes > 0 && total > c.maxBytes)
+	}
+	if !over() {
+		return false
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		return c.index.Entries[a].CreatedAt.Compare(c.index.Entries[b].CreatedAt)
+	})
+	for _, key := range keys {
+		if !over() {
+			break
+		}
+		entry := c.index.Entries[key]
+		delete(c.index.Entries, key)
+		os.Remove(c.entryPath(entry.File))
+		total -= entry.Size
+		c.stats.Evictions++
+	}
+	return true
+}
+
+// loadIndex rereads index.json if it was replaced since it was last
+// read. A missing, unreadable or corrupt index is an empty one. c.mu and
+// the lock file must be held.
+func (c *DiskCache) loadIndex() {
+	stat, err := os.Stat(c.indexPath())
+	if err == nil && c.indexStat != nil && os.SameFile(stat, c.indexStat) && stat.ModTime().Equal(c.indexStat.ModTime()) {
+		return
+	}
+
+	c.index = diskIndex{Version: diskIndexVersion, Entries: make(map[string]diskEntry)}
+	c.indexStat = nil
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(c.indexPath())
+	if err != nil {
+		return
+	}
+
+	var index diskIndex
+	if err := json.Unmarshal(data, &index); err != nil || index.Version != diskIndexVersion || index.Entries == nil {
+		return
+	}
+	c.index = index
+	c.indexStat = stat
+}
+
+// read loads and checks the content file of an entry
+func (c *DiskCache) read(entry diskEntry) ([]byte, error) {
+	stored, err := os.ReadFile(c.entryPath(entry.File))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(stored)
+	if len(stored) != entry.Size || hex.EncodeToString(sum[:]) != entry.SHA256 {
+		return nil, fmt.Errorf("pokecache: %s is corrupt", entry.File)
+	}
+	if !entry.Gzip {
+		return stored, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(stored))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// writeFileAtomic replaces path with data through a temp file and a
+// rename, so readers see either the old or the new file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// a no-op once the rename succeeded
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,315 @@
+package pokecache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheAddGet(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("gzip %v", compressed), func(t *testing.T) {
+			dir := t.TempDir()
+			var opts []DiskOption
+			if compressed {
+				opts = append(opts, WithGzip())
+			}
+			cache, err := NewDiskCache(dir, 0, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cache.Add("https://example.com", []byte("testdata"))
+			cache.Add("https://example.com/empty", []byte{})
+			if val, ok := cache.Get("https://example.com"); !ok || string(val) != "testdata" {
+				t.Errorf("expected testdata, got %q %v", val, ok)
+			}
+			if val, ok := cache.Get("https://example.com/empty"); !ok || len(val) != 0 {
+				t.Errorf("expected an empty value, got %q %v", val, ok)
+			}
+			if _, ok := cache.Get("https://example.com/missing"); ok {
+				t.Errorf("expected a miss for a key never added")
+			}
+
+			// a new cache on the same directory, like after a restart,
+			// sees the entries whether or not it compresses
+			reopened, err := NewDiskCache(dir, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if val, ok := reopened.Get("https://example.com"); !ok || string(val) != "testdata" {
+				t.Errorf("expected testdata after reopening, got %q %v", val, ok)
+			}
+		})
+	}
+}
+
+func TestDiskCacheTTL(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.AddWithTTL("short", []byte("1"), 5*time.Millisecond)
+	cache.AddWithTTL("forever", []byte("2"), 0)
+	cache.Add("default", []byte("3"))
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected the short lived entry to expire")
+	}
+	for _, key := range []string{"forever", "default"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+
+	// the expired entry's file is gone too
+	files, _ := os.ReadDir(filepath.Join(cache.Dir(), "entries"))
+	if len(files) != 2 {
+		t.Errorf("expected 2 content files, got %d", len(files))
+	}
+}
+
+func TestDiskCacheCorruption(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 0, WithGzip())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Add("truncated", []byte("some data that gets cut short"))
+	cache.Add("intact", []byte("fine"))
+
+	// simulate a write cut short by a crash
+	entry := cache.index.Entries["truncated"]
+	path := filepath.Join(dir, "entries", entry.File)
+	if err := os.Truncate(path, int64(entry.Size/2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("truncated"); ok {
+		t.Errorf("expected a truncated entry to be a miss")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the corrupt content file to be removed, got %v", err)
+	}
+	if val, ok := cache.Get("intact"); !ok || string(val) != "fine" {
+		t.Errorf("expected other entries to survive, got %q %v", val, ok)
+	}
+
+	// a garbled index loses every entry but the cache keeps working
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte(`{"version": 1, "entr`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reopened.Get("intact"); ok {
+		t.Errorf("expected a miss with a corrupt index")
+	}
+	reopened.Add("intact", []byte("again"))
+	if val, ok := reopened.Get("intact"); !ok || string(val) != "again" {
+		t.Errorf("expected the cache to recover, got %q %v", val, ok)
+	}
+}
+
+func TestDiskCacheConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+
+	// each cache stands in for a separate process sharing the
+	// directory; without the lock they would overwrite each other's
+	// index updates
+	const writers, keys = 4, 25
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		cache, err := NewDiskCache(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < keys; k++ {
+				cache.Add(fmt.Sprintf("%d/%d", w, k), []byte("x"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	cache, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for w := 0; w < writers; w++ {
+		for k := 0; k < keys; k++ {
+			if _, ok := cache.Get(fmt.Sprintf("%d/%d", w, k)); !ok {
+				t.Errorf("lost entry %d/%d", w, k)
+			}
+		}
+	}
+}
+
+func TestTieredCache(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewTieredCache(NewCache(0), disk)
+	defer cache.Close()
+	cache.AddWithTTL("https://example.com", []byte("testdata"), time.Hour)
+
+	// a fresh memory tier, like after a restart, is filled from disk
+	disk, err = NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted := NewTieredCache(NewCache(0), disk)
+	defer restarted.Close()
+	if val, ok := restarted.Get("https://example.com"); !ok || string(val) != "testdata" {
+		t.Fatalf("expected a disk hit, got %q %v", val, ok)
+	}
+
+	val, ok := restarted.Memory.Get("https://example.com")
+	if !ok || string(val) != "testdata" {
+		t.Errorf("expected the disk hit to be copied into memory")
+	}
+	restarted.Memory.mu.Lock()
+	expiresAt := restarted.Memory.cacheData["https://example.com"].Value.(*cacheEntry).expiresAt
+	restarted.Memory.mu.Unlock()
+	if until := time.Until(expiresAt); until <= 0 || until > time.Hour {
+		t.Errorf("expected the memory copy to expire with the disk entry, got %v", until)
+	}
+}
+
+func TestDiskCacheStats(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Add("b", []byte("123"))
+	cache.Add("a", []byte("12345"))
+	cache.AddWithTTL("short", []byte("1"), 5*time.Millisecond)
+	cache.Get("a")
+	cache.Get("missing")
+	time.Sleep(10 * time.Millisecond)
+	cache.Get("short")
+
+	expected := Stats{Hits: 1, Misses: 2, Expirations: 1, Entries: 2, Bytes: 8}
+	if actual := cache.Stats(); actual != expected {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+	if actual := cache.Keys(); fmt.Sprint(actual) != "[a b]" {
+		t.Errorf("expected keys [a b], got %v", actual)
+	}
+
+	if !cache.Remove("a") || cache.Remove("a") {
+		t.Errorf("expected Remove to report a only once")
+	}
+	cache.Clear()
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected an empty cache after Clear, got %+v", stats)
+	}
+	entries, _ := os.ReadDir(filepath.Join(cache.Dir(), "entries"))
+	if len(entries) != 0 {
+		t.Errorf("expected Clear to delete the content files, found %d", len(entries))
+	}
+}
+
+func TestTieredCacheStats(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disk.Add("https://example.com", []byte("testdata"))
+	cache := NewTieredCache(NewCache(0), disk)
+	defer cache.Close()
+
+	// memory misses and disk serves the first lookup, memory the second
+	for i := 0; i < 2; i++ {
+		if _, ok := cache.Get("https://example.com"); !ok {
+			t.Fatalf("lookup %d: expected a hit", i)
+		}
+	}
+	cache.Get("https://example.com/missing")
+
+	cases := []struct {
+		tier         string
+		stats        Stats
+		hits, misses int
+	}{
+		{tier: "tiered", stats: cache.Stats(), hits: 2, misses: 1},
+		{tier: "memory", stats: cache.Memory.Stats(), hits: 1, misses: 2},
+		{tier: "disk", stats: cache.Disk.Stats(), hits: 1, misses: 1},
+	}
+	for _, c := range cases {
+		if c.stats.Hits != c.hits || c.stats.Misses != c.misses {
+			t.Errorf("%s: expected %d hits and %d misses, got %+v", c.tier, c.hits, c.misses, c.stats)
+		}
+	}
+}
+
+func TestDiskEntrySame(t *testing.T) {
+	entry := diskEntry{File: "abc", CreatedAt: time.Now(), SHA256: "123"}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reloaded diskEntry
+	if err := json.Unmarshal(data, &reloaded); err != nil {
+		t.Fatal(err)
+	}
+
+	// the monotonic clock reading doesn't survive JSON
+	if reloaded == entry {
+		t.Fatalf("expected == to tell the reloaded entry apart")
+	}
+	if !reloaded.same(entry) {
+		t.Errorf("expected an entry read back from the index to be the same write")
+	}
+	rewritten := entry
+	rewritten.CreatedAt = entry.CreatedAt.Add(time.Second)
+	if rewritten.same(entry) {
+		t.Errorf("expected a later write of the same content to be another write")
+	}
+}
+
+func TestDiskCacheLimits(t *testing.T) {
+	cases := []struct {
+		name     string
+		opt      DiskOption
+		expected []string
+	}{
+		{name: "max entries", opt: WithDiskMaxEntries(2), expected: []string{"b", "c"}},
+		// a and b are 5 bytes each, c is 3
+		{name: "max bytes", opt: WithDiskMaxBytes(7), expected: []string{"c"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache, err := NewDiskCache(t.TempDir(), 0, c.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache.Add("a", []byte("12345"))
+			cache.Add("b", []byte("12345"))
+			cache.Add("c", []byte("123"))
+
+			if actual := cache.Keys(); !slices.Equal(actual, c.expected) {
+				t.Errorf("expected %v to be left, got %v", c.expected, actual)
+			}
+			entries, _ := os.ReadDir(filepath.Join(cache.Dir(), "entries"))
+			if len(entries) != len(c.expected) {
+				t.Errorf("expected the content files of pruned entries to be deleted, found %d", len(entries))
+			}
+			if stats := cache.Stats(); stats.Evictions != 3-len(c.expected) {
+				t.Errorf("expected %d evictions, got %+v", 3-len(c.expected), stats)
+			}
+		})
+	}
+}
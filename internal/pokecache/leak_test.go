@@ -0,0 +1,61 @@
+package pokecache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokecache"
+	"github.com/snyderg13/pokedex/internal/testutil"
+)
+
+// TestMain fails the package if any test leaves a reapLoop running
+func TestMain(m *testing.M) {
+	testutil.CheckReapers(m)
+}
+
+func TestClose(t *testing.T) {
+	before := pokecache.Reapers()
+
+	caches := make([]*pokecache.Cache, 10)
+	for i := range caches {
+		caches[i] = pokecache.NewCache(time.Millisecond)
+	}
+	if got := pokecache.Reapers(); got != before+len(caches) {
+		t.Fatalf("expected %d reapLoops, got %d", before+len(caches), got)
+	}
+
+	for _, c := range caches {
+		if err := c.Close(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	// Close waits for the reapLoop, so nothing is left right away
+	if got := pokecache.Reapers(); got != before {
+		t.Errorf("expected %d reapLoops after Close, got %d", before, got)
+	}
+
+	// closing twice is fine and the cache still works
+	caches[0].Close()
+	caches[0].Add("key", []byte("val"))
+	if _, ok := caches[0].Get("key"); !ok {
+		t.Errorf("expected a closed cache to keep working")
+	}
+}
+
+func TestNewCacheContext(t *testing.T) {
+	before := pokecache.Reapers()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := pokecache.NewCacheContext(ctx, time.Millisecond)
+	if got := pokecache.Reapers(); got != before+1 {
+		t.Fatalf("expected a reapLoop to start, got %d", got)
+	}
+
+	cancel()
+	if got := testutil.WaitForReapers(before); got != before {
+		t.Errorf("expected the reapLoop to stop with its context, got %d", got)
+	}
+	// Close after the context ended doesn't block
+	cache.Close()
+}
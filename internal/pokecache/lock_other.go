@@ -0,0 +1,21 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package pokecache
+
+import (
+	"sync"
+)
+
+// there's no flock here, so the lock only covers this process; another
+// process sharing the directory may lose an index update, which only
+// costs a cache entry
+var processLock sync.RWMutex
+
+func lockFile(path string, exclusive bool) (unlock func(), err error) {
+	if exclusive {
+		processLock.Lock()
+		return processLock.Unlock, nil
+	}
+	processLock.RLock()
+	return processLock.RUnlock, nil
+}
@@ -0,0 +1,85 @@
+package pokecache
+
+import (
+	"sync"
+	"time"
+)
+
+// TieredCache puts a Cache in front of a DiskCache. Reads are served from
+// memory when possible and disk hits are copied into memory; writes go to
+// both.
+type TieredCache struct {
+	Memory *Cache
+	Disk   *DiskCache
+
+	mu           sync.Mutex
+	hits, misses int
+}
+
+// NewTieredCache combines a memory and a disk cache
+func NewTieredCache(memory *Cache, disk *DiskCache) *TieredCache {
+	return &TieredCache{Memory: memory, Disk: disk}
+}
+
+// Add caches val in both tiers for each tier's default ttl
+func (c *TieredCache) Add(key string, val []byte) {
+	c.Memory.Add(key, val)
+	c.Disk.Add(key, val)
+}
+
+// AddWithTTL caches val in both tiers for ttl, or forever if ttl is 0 or
+// less
+func (c *TieredCache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.Memory.AddWithTTL(key, val, ttl)
+	c.Disk.AddWithTTL(key, val, ttl)
+}
+
+// Close stops the memory tier's reapLoop; the disk tier has nothing
+// running in the background
+func (c *TieredCache) Close() error {
+	return c.Memory.Close()
+}
+
+// Get looks in memory first, then on disk
+func (c *TieredCache) Get(key string) ([]byte, bool) {
+	val, ok := c.get(key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return val, ok
+}
+
+func (c *TieredCache) get(key string) ([]byte, bool) {
+	if val, ok := c.Memory.Get(key); ok {
+		return val, true
+	}
+
+	val, expiresAt, ok := c.Disk.get(key)
+	if !ok {
+		return nil, false
+	}
+	// keep the entry in memory only for as long as it has left
+	var ttl time.Duration
+	if !expiresAt.IsZero() {
+		ttl = time.Until(expiresAt)
+		if ttl <= 0 {
+			return nil, false
+		}
+	}
+	c.Memory.AddWithTTL(key, val, ttl)
+	return val, true
+}
+
+// Stats counts the lookups of the two tiers together: a hit is one
+// either tier served and a miss one neither could. Only Hits and Misses
+// are set; Memory.Stats and Disk.Stats have the rest, with each tier
+// counting its own lookups, so a disk hit is also a memory miss there.
+func (c *TieredCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.hits, Misses: c.misses}
+}
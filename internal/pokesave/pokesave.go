@@ -0,0 +1,280 @@
+package pokesave
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// CurrentVersion is the schema version written by Save. Bump it whenever
+// SaveFile changes in a way old files can't be read as-is, and add a
+// migration from the previous version.
+const CurrentVersion = 5
+
+// ErrNoSave is returned by Load when there is no save file yet
+var ErrNoSave = errors.New("pokesave: no save file")
+
+// SaveFile is everything that is persisted between sessions
+type SaveFile struct {
+	Version int             `json:"version"`
+	SavedAt time.Time       `json:"saved_at"`
+	Trainer trainer.Trainer `json:"trainer"`
+}
+
+// migration upgrades the raw JSON of a save file by one version
+type migration func(raw map[string]json.RawMessage) error
+
+// migrations[n] upgrades a version n file to version n+1
+var migrations = map[int]migration{
+	1: migrateV1,
+	2: migrateV2,
+	3: migrateV3,
+	4: migrateV4,
+}
+
+// version 1 only had the caught pokemon; they now belong to a trainer
+func migrateV1(raw map[string]json.RawMessage) error {
+	pokedex, ok := raw["pokedex"]
+	if !ok {
+		pokedex = json.RawMessage("{}")
+	}
+	t, err := json.Marshal(map[string]json.RawMessage{"pokedex": pokedex})
+	if err != nil {
+		return err
+	}
+	raw["trainer"] = t
+	delete(raw, "pokedex")
+	return nil
+}
+
+// version 2 kept one entry per species; each of them becomes a caught
+// pokemon of its own, caught when the file was last saved
+func migrateV2(raw map[string]json.RawMessage) error {
+	var t map[string]json.RawMessage
+	if err := json.Unmarshal(raw["trainer"], &t); err != nil {
+		return err
+	}
+	var pokedex map[string]json.RawMessage
+	if p, ok := t["pokedex"]; ok {
+		if err := json.Unmarshal(p, &pokedex); err != nil {
+			return err
+		}
+	}
+	var savedAt time.Time
+	if v, ok := raw["saved_at"]; ok {
+		if err := json.Unmarshal(v, &savedAt); err != nil {
+			return err
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(pokedex))
+	caught := make([]trainer.Pokemon, 0, len(names))
+	for i, name := range names {
+		caught = append(caught, trainer.Pokemon{
+			ID:       i + 1,
+			Species:  name,
+			CaughtAt: savedAt,
+			Level:    trainer.DefaultLevel,
+			IVs:      map[string]int{},
+		})
+	}
+
+	var err error
+	if t["pokemon"], err = json.Marshal(caught); err != nil {
+		return err
+	}
+	if t["next_id"], err = json.Marshal(len(caught) + 1); err != nil {
+		return err
+	}
+	raw["trainer"], err = json.Marshal(t)
+	return err
+}
+
+// version 3 called the caught species data "pokedex"; it is now
+// "species" and the pokedex tracks seen and caught species, which
+// for old saves are the caught ones
+func migrateV3(raw map[string]json.RawMessage) error {
+	var t map[string]json.RawMessage
+	if err := json.Unmarshal(raw["trainer"], &t); err != nil {
+		return err
+	}
+	var species map[string]struct {
+		ID int `json:"id"`
+	}
+	if p, ok := t["pokedex"]; ok {
+		if err := json.Unmarshal(p, &species); err != nil {
+			return err
+		}
+	}
+
+	pokedex := make(map[string]trainer.DexEntry, len(species))
+	for name, s := range species {
+		pokedex[name] = trainer.DexEntry{Name: name, Number: s.ID, Caught: true}
+	}
+
+	var err error
+	t["species"] = t["pokedex"]
+	if t["pokedex"], err = json.Marshal(pokedex); err != nil {
+		return err
+	}
+	raw["trainer"], err = json.Marshal(t)
+	return err
+}
+
+// version 4 had no party or boxes; pokemon fill the party
+// in the order they were caught and the rest go to boxes
+func migrateV4(raw map[string]json.RawMessage) error {
+	var t map[string]json.RawMessage
+	if err := json.Unmarshal(raw["trainer"], &t); err != nil {
+		return err
+	}
+	var caught []struct {
+		ID int `json:"id"`
+	}
+	if p, ok := t["pokemon"]; ok {
+		if err := json.Unmarshal(p, &caught); err != nil {
+			return err
+		}
+	}
+
+	party := []int{}
+	boxes := [][]int{}
+	for _, p := range caught {
+		if len(party) < trainer.PartySize {
+			party = append(party, p.ID)
+			continue
+		}
+		if len(boxes) == 0 || len(boxes[len(boxes)-1]) >= trainer.BoxSize {
+			boxes = append(boxes, []int{})
+		}
+		boxes[len(boxes)-1] = append(boxes[len(boxes)-1], p.ID)
+	}
+
+	var err error
+	if t["party"], err = json.Marshal(party); err != nil {
+		return err
+	}
+	if t["boxes"], err = json.Marshal(boxes); err != nil {
+		return err
+	}
+	raw["trainer"], err = json.Marshal(t)
+	return err
+}
+
+// DefaultDir returns where save data lives: $XDG_DATA_HOME/pokedex,
+// falling back to ~/.local/share/pokedex
+func DefaultDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "pokedex"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("pokesave: can't find a data directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "pokedex"), nil
+}
+
+// Load reads and, if needed, migrates the save file at path
+func Load(path string) (SaveFile, error) {
+	var save SaveFile
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return save, ErrNoSave
+	} else if err != nil {
+		return save, fmt.Errorf("pokesave: failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return save, fmt.Errorf("pokesave: %s is not a save file: %w", path, err)
+	}
+
+	version := 0
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return save, fmt.Errorf("pokesave: bad version in %s: %w", path, err)
+		}
+	}
+	if version > CurrentVersion {
+		return save, fmt.Errorf("pokesave: %s was written by a newer version (%d > %d)", path, version, CurrentVersion)
+	}
+
+	for ; version < CurrentVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return save, fmt.Errorf("pokesave: no migration from version %d", version)
+		}
+		if err := migrate(raw); err != nil {
+			return save, fmt.Errorf("pokesave: migrating from version %d: %w", version, err)
+		}
+	}
+
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return save, err
+	}
+	if err := json.Unmarshal(data, &save); err != nil {
+		return save, fmt.Errorf("pokesave: failed to decode %s: %w", path, err)
+	}
+	save.Version = CurrentVersion
+	if save.Trainer.Species == nil {
+		save.Trainer.Species = make(map[string]pokeapi.PokemonSummary)
+	}
+	if save.Trainer.Pokedex == nil {
+		save.Trainer.Pokedex = make(map[string]trainer.DexEntry)
+	}
+
+	return save, nil
+}
+
+// Save writes save to path. The data is written to a temporary file in
+// the same directory which is then renamed over path, so a crash halfway
+// through never leaves a truncated save behind.
+func Save(path string, save SaveFile) error {
+	save.Version = CurrentVersion
+	save.SavedAt = time.Now()
+
+	// saves are rewritten often, keep them compact
+	data, err := json.Marshal(save)
+	if err != nil {
+		return fmt.Errorf("pokesave: failed to encode save: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("pokesave: failed to create %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("pokesave: failed to create temp file: %w", err)
+	}
+	// a no-op once the rename succeeded
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("pokesave: failed to write save: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("pokesave: failed to sync save: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("pokesave: failed to write save: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("pokesave: failed to replace %s: %w", path, err)
+	}
+	return nil
+}
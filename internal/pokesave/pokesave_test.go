@@ -0,0 +1,248 @@
+package pokesave
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "save.json")
+	save := SaveFile{
+		Trainer: trainer.Trainer{
+			Name:  "ash",
+			Stats: trainer.Stats{BallsThrown: 3, Caught: 1, Escaped: 2},
+			Species: map[string]pokeapi.PokemonSummary{
+				"pikachu": {ID: 25, Name: "pikachu", BaseExperience: 112},
+			},
+		},
+	}
+
+	if err := Save(path, save); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loaded.Version != CurrentVersion {
+		t.Errorf("expected version %d, got %d", CurrentVersion, loaded.Version)
+	}
+	if loaded.SavedAt.IsZero() {
+		t.Errorf("expected SavedAt to be set")
+	}
+	if loaded.Trainer.Name != "ash" || loaded.Trainer.Stats != save.Trainer.Stats {
+		t.Errorf("unexpected trainer: %+v", loaded.Trainer)
+	}
+	if p := loaded.Trainer.Species["pikachu"]; p.ID != 25 || p.BaseExperience != 112 {
+		t.Errorf("unexpected pokemon: %+v", p)
+	}
+
+	// the temp file must be gone after the rename
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the save file, found %d entries", len(entries))
+	}
+}
+
+func TestSaveReplacesExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	for _, name := range []string{"bulbasaur", "charmander"} {
+		save := SaveFile{Trainer: trainer.Trainer{Species: map[string]pokeapi.PokemonSummary{name: {Name: name}}}}
+		err := Save(path, save)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := loaded.Trainer.Species["charmander"]; !ok || len(loaded.Trainer.Species) != 1 {
+		t.Errorf("expected only the latest save, got %v", loaded.Trainer.Species)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return path
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); !errors.Is(err, ErrNoSave) {
+		t.Errorf("expected ErrNoSave, got %v", err)
+	}
+	if _, err := Load(write("garbage.json", `{"version": 1, "pokedex": `)); err == nil {
+		t.Errorf("expected an error for a truncated file")
+	}
+	if _, err := Load(write("future.json", `{"version": 999}`)); err == nil {
+		t.Errorf("expected an error for a save from a newer version")
+	}
+}
+
+func TestLoadMigrates(t *testing.T) {
+	// pretend version 0 stored the pokedex under a different key
+	migrations[0] = func(raw map[string]json.RawMessage) error {
+		raw["pokedex"] = raw["caught"]
+		delete(raw, "caught")
+		return nil
+	}
+	defer delete(migrations, 0)
+
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"caught": {"ditto": {"id": 132, "name": "ditto"}}}`), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := loaded.Trainer.Species["ditto"]; p.ID != 132 {
+		t.Errorf("expected migrated ditto, got %+v", loaded.Trainer.Species)
+	}
+}
+
+func TestLoadMigratesV1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	v1 := `{"version": 1, "saved_at": "2025-06-01T10:00:00Z", "pokedex": {"pikachu": {"id": 25, "name": "pikachu"}}}`
+	if err := os.WriteFile(path, []byte(v1), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := loaded.Trainer.Species["pikachu"]; p.ID != 25 {
+		t.Errorf("expected pikachu to move to the trainer, got %+v", loaded.Trainer)
+	}
+}
+
+func TestLoadMigratesV2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	v2 := `{"version": 2, "saved_at": "2025-06-01T10:00:00Z", "trainer": {"name": "ash", "pokedex": {
+		"pikachu": {"id": 25, "name": "pikachu"},
+		"bulbasaur": {"id": 1, "name": "bulbasaur"}
+	}}}`
+	if err := os.WriteFile(path, []byte(v2), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caught := loaded.Trainer.Pokemon
+	if len(caught) != 2 || caught[0].Species != "bulbasaur" || caught[1].Species != "pikachu" {
+		t.Fatalf("expected one pokemon per species, got %+v", caught)
+	}
+	if caught[1].ID != 2 || caught[1].Level != trainer.DefaultLevel || caught[1].CaughtAt.Year() != 2025 {
+		t.Errorf("unexpected migrated pokemon: %+v", caught[1])
+	}
+	if loaded.Trainer.NextID != 3 {
+		t.Errorf("expected next id 3, got %d", loaded.Trainer.NextID)
+	}
+}
+
+func TestLoadMigratesV3(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	v3 := `{"version": 3, "trainer": {"name": "ash", "pokedex": {"pikachu": {"id": 25, "name": "pikachu"}}}}`
+	if err := os.WriteFile(path, []byte(v3), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := loaded.Trainer.Species["pikachu"]; p.ID != 25 {
+		t.Errorf("expected pikachu's data under species, got %+v", loaded.Trainer.Species)
+	}
+	expected := trainer.DexEntry{Name: "pikachu", Number: 25, Caught: true}
+	if e := loaded.Trainer.Pokedex["pikachu"]; e != expected {
+		t.Errorf("expected %+v in the pokedex, got %+v", expected, e)
+	}
+}
+
+func TestLoadMigratesV4(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	pokemon := ""
+	for id := 1; id <= trainer.PartySize+2; id++ {
+		if id > 1 {
+			pokemon += ","
+		}
+		pokemon += `{"id": ` + strconv.Itoa(id) + `, "species": "magikarp"}`
+	}
+	v4 := `{"version": 4, "trainer": {"name": "ash", "pokemon": [` + pokemon + `]}}`
+	if err := os.WriteFile(path, []byte(v4), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(loaded.Trainer.Party, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("unexpected party: %v", loaded.Trainer.Party)
+	}
+	if len(loaded.Trainer.Boxes) != 1 || !slices.Equal(loaded.Trainer.Boxes[0], []int{7, 8}) {
+		t.Errorf("unexpected boxes: %v", loaded.Trainer.Boxes)
+	}
+}
+
+func TestSaveKeepsSpeciesSummary(t *testing.T) {
+	// a full pokemon as the API returns it, as older versions saved it
+	var pikachu pokeapi.PokemonStats
+	full := `{"id": 25, "name": "pikachu", "base_experience": 112,
+		"species": {"name": "pikachu"},
+		"stats": [{"base_stat": 35, "stat": {"name": "hp"}}],
+		"types": [{"slot": 1, "type": {"name": "electric"}}],
+		"moves": [{"move": {"name": "thunder-shock"}, "version_group_details": [{"level_learned_at": 1}]}],
+		"sprites": {"front_default": "https://example.com/25.png"}}`
+	if err := json.Unmarshal([]byte(full), &pikachu); err != nil {
+		t.Fatal(err)
+	}
+	tr := trainer.New("ash")
+	tr.RecordThrow(pikachu, &trainer.Pokemon{})
+
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := Save(path, SaveFile{Trainer: *tr}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"moves"`, `"sprites"`, "\n"} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("expected a compact save with only the species summary, found %q in %s", field, data)
+		}
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := loaded.Trainer.Species["pikachu"]
+	if p.ID != 25 || p.BaseExperience != 112 || p.Species.Name != "pikachu" || len(p.Stats) != 1 || p.Types[0].Type.Name != "electric" {
+		t.Errorf("unexpected species summary: %+v", p)
+	}
+}
@@ -0,0 +1,161 @@
+package pokesave
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultProfile is used when no profile was ever picked
+	DefaultProfile = "default"
+
+	profilesDir       = "profiles"
+	activeProfileFile = "active-profile"
+	legacySaveFile    = "save.json"
+	profileExt        = ".json"
+)
+
+var (
+	ErrProfileExists   = errors.New("pokesave: profile already exists")
+	ErrNoProfile       = errors.New("pokesave: no such profile")
+	ErrInvalidProfile  = errors.New("pokesave: profile names may only use a-z, 0-9, - and _")
+	ErrProfileIsActive = errors.New("pokesave: can't delete the active profile")
+)
+
+var profileNameRE = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Store keeps one save file per profile under Dir/profiles and
+// remembers which profile was used last
+type Store struct {
+	Dir string
+}
+
+// NewStore returns a Store rooted at dir. A save.json left behind by
+// versions without profiles is moved into the default profile.
+func NewStore(dir string) (*Store, error) {
+	s := &Store{Dir: dir}
+
+	legacy := filepath.Join(dir, legacySaveFile)
+	if _, err := os.Stat(legacy); err == nil {
+		target := s.path(DefaultProfile)
+		if _, err := os.Stat(target); errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return nil, fmt.Errorf("pokesave: failed to create profiles dir: %w", err)
+			}
+			if err := os.Rename(legacy, target); err != nil {
+				return nil, fmt.Errorf("pokesave: failed to move old save into the default profile: %w", err)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.Dir, profilesDir, name+profileExt)
+}
+
+func validateName(name string) error {
+	if !profileNameRE.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidProfile, name)
+	}
+	return nil
+}
+
+// Exists reports whether a profile has a save file
+func (s *Store) Exists(name string) bool {
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}
+
+// Profiles lists the names of all profiles, sorted
+func (s *Store) Profiles() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.Dir, profilesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("pokesave: failed to list profiles: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), profileExt)
+		if ok && !e.IsDir() && profileNameRE.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Create starts a new profile with the given save data
+func (s *Store) Create(name string, save SaveFile) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if s.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+	return s.Save(name, save)
+}
+
+// Load reads a profile's save file. A profile that was never saved
+// returns ErrNoSave.
+func (s *Store) Load(name string) (SaveFile, error) {
+	if err := validateName(name); err != nil {
+		return SaveFile{}, err
+	}
+	return Load(s.path(name))
+}
+
+// Save writes a profile's save file
+func (s *Store) Save(name string, save SaveFile) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	return Save(s.path(name), save)
+}
+
+// Delete removes a profile. The active profile can't be deleted.
+func (s *Store) Delete(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if name == s.Active() {
+		return fmt.Errorf("%w: %s", ErrProfileIsActive, name)
+	}
+	err := os.Remove(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNoProfile, name)
+	}
+	return err
+}
+
+// Active returns the profile used last, or DefaultProfile
+func (s *Store) Active() string {
+	data, err := os.ReadFile(filepath.Join(s.Dir, activeProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	name := strings.TrimSpace(string(data))
+	if validateName(name) != nil {
+		return DefaultProfile
+	}
+	return name
+}
+
+// SetActive remembers name as the profile to use next time
+func (s *Store) SetActive(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("pokesave: failed to create %s: %w", s.Dir, err)
+	}
+	return os.WriteFile(filepath.Join(s.Dir, activeProfileFile), []byte(name+"\n"), 0o644)
+}
@@ -0,0 +1,86 @@
+package pokesave
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func TestProfiles(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := store.Active(); got != DefaultProfile {
+		t.Errorf("expected %s to be active, got %s", DefaultProfile, got)
+	}
+
+	for _, name := range []string{"misty", "brock"} {
+		if err := store.Create(name, SaveFile{Trainer: *trainer.New(name)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := store.Create("misty", SaveFile{}); !errors.Is(err, ErrProfileExists) {
+		t.Errorf("expected ErrProfileExists, got %v", err)
+	}
+	if err := store.Create("../escape", SaveFile{}); !errors.Is(err, ErrInvalidProfile) {
+		t.Errorf("expected ErrInvalidProfile, got %v", err)
+	}
+
+	names, err := store.Profiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(names, []string{"brock", "misty"}) {
+		t.Errorf("unexpected profiles: %v", names)
+	}
+
+	save, err := store.Load("misty")
+	if err != nil || save.Trainer.Name != "misty" {
+		t.Errorf("expected misty's save, got %+v, %v", save, err)
+	}
+
+	if err := store.SetActive("misty"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.Active(); got != "misty" {
+		t.Errorf("expected misty to be active, got %s", got)
+	}
+	if err := store.Delete("misty"); !errors.Is(err, ErrProfileIsActive) {
+		t.Errorf("expected ErrProfileIsActive, got %v", err)
+	}
+	if err := store.Delete("brock"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := store.Delete("brock"); !errors.Is(err, ErrNoProfile) {
+		t.Errorf("expected ErrNoProfile, got %v", err)
+	}
+	if store.Exists("brock") {
+		t.Errorf("expected brock to be gone")
+	}
+}
+
+func TestNewStoreMovesLegacySave(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"version": 1, "pokedex": {"eevee": {"id": 133, "name": "eevee"}}}`
+	if err := os.WriteFile(filepath.Join(dir, "save.json"), []byte(legacy), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	save, err := store.Load(DefaultProfile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := save.Trainer.Species["eevee"]; !ok {
+		t.Errorf("expected the old save in the default profile, got %+v", save.Trainer)
+	}
+}
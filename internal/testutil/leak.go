@@ -0,0 +1,43 @@
+// Package testutil holds helpers shared by the tests of several packages.
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokecache"
+)
+
+// WaitForReapers waits up to a second for the number of running cache
+// reapLoops to drop to want, since a reapLoop stopped by its context can
+// take a moment to exit. It returns the last count seen.
+func WaitForReapers(want int) int {
+	deadline := time.Now().Add(time.Second)
+	for {
+		got := pokecache.Reapers()
+		if got <= want || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// CheckReapers runs the tests and fails them if any left a cache reapLoop
+// running, e.g. by not closing a cache or a client that owns one. Call it
+// from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		testutil.CheckReapers(m)
+//	}
+func CheckReapers(m *testing.M) {
+	code := m.Run()
+	if code == 0 {
+		if n := WaitForReapers(0); n > 0 {
+			fmt.Fprintf(os.Stderr, "%d cache reapLoops leaked, close every cache and client a test creates\n", n)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,85 @@
+package trainer
+
+import (
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// Evolution triggers, named like PokeAPI's evolution-trigger resource
+const (
+	TriggerLevelUp = "level-up"
+	TriggerUseItem = "use-item"
+	TriggerTrade   = "trade"
+)
+
+// Trigger is something that happened to a pokemon that might make it
+// evolve
+type Trigger struct {
+	// Kind is one of TriggerLevelUp, TriggerUseItem or TriggerTrade
+	Kind string
+	// Item is the item used, or held while being traded
+	Item string
+	// Time is when it happened, for evolutions that need day or night
+	Time time.Time
+}
+
+// TimeOfDay returns "night" from 20:00 to 03:59 and "day" otherwise
+func TimeOfDay(t time.Time) string {
+	if h := t.Hour(); h >= 20 || h < 4 {
+		return "night"
+	}
+	return "day"
+}
+
+// CanEvolve reports whether the pokemon meets every condition of an
+// evolution when trig happens. Evolutions with conditions the game
+// doesn't model never match.
+func (p *Pokemon) CanEvolve(d pokeapi.EvolutionDetail, trig Trigger) bool {
+	if d.Unsupported() || d.Trigger.Name != trig.Kind {
+		return false
+	}
+
+	switch {
+	case trig.Kind == TriggerUseItem && d.Item.Name != trig.Item:
+		return false
+	case trig.Kind == TriggerTrade && d.HeldItem.Name != "" && d.HeldItem.Name != trig.Item:
+		return false
+	// pokemon can't hold items outside of a trade
+	case trig.Kind != TriggerTrade && d.HeldItem.Name != "":
+		return false
+	case d.MinLevel > 0 && p.Level < d.MinLevel:
+		return false
+	case d.MinHappiness > 0 && p.Friendship < d.MinHappiness:
+		return false
+	case d.TimeOfDay != "" && d.TimeOfDay != TimeOfDay(trig.Time):
+		return false
+	}
+	return true
+}
+
+// EvolvesInto returns the species the pokemon evolves into when trig
+// happens, given its own link in the evolution chain. It returns an empty
+// string if it doesn't evolve.
+func (p *Pokemon) EvolvesInto(link *pokeapi.ChainLink, trig Trigger) string {
+	if link == nil {
+		return ""
+	}
+	for _, next := range link.EvolvesTo {
+		for _, d := range next.EvolutionDetails {
+			if p.CanEvolve(d, trig) {
+				return next.Species.Name
+			}
+		}
+	}
+	return ""
+}
+
+// Evolve turns a pokemon into another species. It keeps its ID,
+// nickname, level, experience and where it is stored; the new species is
+// registered as caught.
+func (t *Trainer) Evolve(p *Pokemon, into pokeapi.PokemonStats) {
+	t.See(into.Name, into.ID)
+	t.registerCaught(into)
+	p.Species = into.Name
+}
@@ -0,0 +1,98 @@
+package trainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+func TestCanEvolve(t *testing.T) {
+	day := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+
+	levelUp := func(level int) pokeapi.EvolutionDetail {
+		return pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: TriggerLevelUp}, MinLevel: level}
+	}
+	stone := pokeapi.EvolutionDetail{
+		Trigger: pokeapi.NamedResource{Name: TriggerUseItem},
+		Item:    pokeapi.NamedResource{Name: "thunder-stone"},
+	}
+	tradeHolding := pokeapi.EvolutionDetail{
+		Trigger:  pokeapi.NamedResource{Name: TriggerTrade},
+		HeldItem: pokeapi.NamedResource{Name: "metal-coat"},
+	}
+	friendshipAtNight := pokeapi.EvolutionDetail{
+		Trigger:      pokeapi.NamedResource{Name: TriggerLevelUp},
+		MinHappiness: 160,
+		TimeOfDay:    "night",
+	}
+	rain := levelUp(50)
+	rain.NeedsOverworldRain = true
+
+	cases := []struct {
+		name     string
+		pokemon  Pokemon
+		detail   pokeapi.EvolutionDetail
+		trig     Trigger
+		expected bool
+	}{
+		{name: "level reached", pokemon: Pokemon{Level: 16}, detail: levelUp(16), trig: Trigger{Kind: TriggerLevelUp}, expected: true},
+		{name: "level too low", pokemon: Pokemon{Level: 15}, detail: levelUp(16), trig: Trigger{Kind: TriggerLevelUp}, expected: false},
+		{name: "wrong trigger", pokemon: Pokemon{Level: 20}, detail: levelUp(16), trig: Trigger{Kind: TriggerTrade}, expected: false},
+		{name: "right stone", detail: stone, trig: Trigger{Kind: TriggerUseItem, Item: "thunder-stone"}, expected: true},
+		{name: "wrong stone", detail: stone, trig: Trigger{Kind: TriggerUseItem, Item: "fire-stone"}, expected: false},
+		{name: "trade holding item", detail: tradeHolding, trig: Trigger{Kind: TriggerTrade, Item: "metal-coat"}, expected: true},
+		{name: "trade without item", detail: tradeHolding, trig: Trigger{Kind: TriggerTrade}, expected: false},
+		{name: "friendly at night", pokemon: Pokemon{Friendship: 200}, detail: friendshipAtNight, trig: Trigger{Kind: TriggerLevelUp, Time: night}, expected: true},
+		{name: "friendly at day", pokemon: Pokemon{Friendship: 200}, detail: friendshipAtNight, trig: Trigger{Kind: TriggerLevelUp, Time: day}, expected: false},
+		{name: "not friendly enough", pokemon: Pokemon{Friendship: 100}, detail: friendshipAtNight, trig: Trigger{Kind: TriggerLevelUp, Time: night}, expected: false},
+		{name: "unsupported condition", pokemon: Pokemon{Level: 60}, detail: rain, trig: Trigger{Kind: TriggerLevelUp}, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.pokemon.CanEvolve(c.detail, c.trig); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEvolve(t *testing.T) {
+	tr := New("ash")
+	tr.RecordThrow(pokeapi.PokemonStats{ID: 25, Name: "pikachu"}, &Pokemon{Nickname: "sparky", Level: 30, Exp: 27000})
+	p, _ := tr.Find(1)
+
+	link := &pokeapi.ChainLink{
+		Species: pokeapi.NamedResource{Name: "pikachu"},
+		EvolvesTo: []pokeapi.ChainLink{{
+			Species: pokeapi.NamedResource{Name: "raichu"},
+			EvolutionDetails: []pokeapi.EvolutionDetail{{
+				Trigger: pokeapi.NamedResource{Name: TriggerUseItem},
+				Item:    pokeapi.NamedResource{Name: "thunder-stone"},
+			}},
+		}},
+	}
+	if into := p.EvolvesInto(link, Trigger{Kind: TriggerLevelUp}); into != "" {
+		t.Errorf("expected no evolution on level up, got %q", into)
+	}
+	into := p.EvolvesInto(link, Trigger{Kind: TriggerUseItem, Item: "thunder-stone"})
+	if into != "raichu" {
+		t.Fatalf("expected raichu, got %q", into)
+	}
+
+	tr.Evolve(p, pokeapi.PokemonStats{ID: 26, Name: into})
+	if p.ID != 1 || p.Species != "raichu" || p.Nickname != "sparky" || p.Level != 30 || p.Exp != 27000 {
+		t.Errorf("unexpected evolved pokemon: %+v", p)
+	}
+	if e := tr.Pokedex["raichu"]; !e.Caught || e.Number != 26 {
+		t.Errorf("expected raichu to be caught in the pokedex, got %+v", e)
+	}
+	if _, ok := tr.Species["raichu"]; !ok {
+		t.Error("expected raichu species data to be kept")
+	}
+	if place, err := tr.Where(1); err != nil || !place.InParty() {
+		t.Errorf("expected the evolved pokemon to stay in the party, got %v", place)
+	}
+}
@@ -0,0 +1,70 @@
+package trainer
+
+import (
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// MaxLevel is the highest level a pokemon can reach
+const MaxLevel = 100
+
+// ExpYield is the experience earned for defeating or catching a wild
+// pokemon, using the generation I-IV formula base * level / 7
+func ExpYield(baseExperience, level int) int {
+	return max(baseExperience*level/7, 1)
+}
+
+// GainExp adds experience to the pokemon and recalculates its level with
+// the species' growth rate. It returns how many levels were gained.
+func (p *Pokemon) GainExp(amount int, rate pokeapi.GrowthRate) int {
+	// pokemon from old saves only know their level; start them
+	// at the experience that level needs
+	p.Exp = max(p.Exp, rate.ExperienceFor(p.Level))
+	if p.GrowthRate == "" {
+		p.GrowthRate = rate.Name
+	}
+
+	maxExp := rate.ExperienceFor(MaxLevel)
+	p.Exp = min(p.Exp+max(amount, 0), maxExp)
+
+	before := p.Level
+	p.Level = max(rate.LevelFor(p.Exp), p.Level)
+	for range p.Level - before {
+		p.befriend()
+	}
+	return p.Level - before
+}
+
+// befriend raises friendship for a level up; like the games, the
+// friendlier a pokemon already is the slower it grows
+func (p *Pokemon) befriend() {
+	switch {
+	case p.Friendship < 100:
+		p.Friendship += 5
+	case p.Friendship < 200:
+		p.Friendship += 3
+	default:
+		p.Friendship += 2
+	}
+	p.Friendship = min(p.Friendship, MaxFriendship)
+}
+
+// CalcStat returns the value of a stat at a level, using the formula of
+// the games from generation III on with no effort values and a neutral
+// nature
+func CalcStat(stat string, base, iv, level int) int {
+	value := (2*base + iv) * level / 100
+	if stat == "hp" {
+		return value + level + 10
+	}
+	return value + 5
+}
+
+// CurrentStats returns the pokemon's stats at its current level, keyed by
+// stat name, given the base stats of its species
+func (p *Pokemon) CurrentStats(species pokeapi.PokemonSummary) map[string]int {
+	stats := make(map[string]int, len(species.Stats))
+	for _, s := range species.Stats {
+		stats[s.Stat.Name] = CalcStat(s.Stat.Name, s.BaseStat, p.IVs[s.Stat.Name], p.Level)
+	}
+	return stats
+}
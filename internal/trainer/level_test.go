@@ -0,0 +1,94 @@
+package trainer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// mediumRate returns the medium (n^3) growth rate up to level 10
+func mediumRate(t *testing.T) pokeapi.GrowthRate {
+	t.Helper()
+	var rate pokeapi.GrowthRate
+	data := `{"name": "medium", "levels": [
+		{"level": 1, "experience": 0}, {"level": 2, "experience": 8},
+		{"level": 3, "experience": 27}, {"level": 4, "experience": 64},
+		{"level": 5, "experience": 125}, {"level": 6, "experience": 216},
+		{"level": 7, "experience": 343}, {"level": 8, "experience": 512},
+		{"level": 9, "experience": 729}, {"level": 10, "experience": 1000}
+	]}`
+	if err := json.Unmarshal([]byte(data), &rate); err != nil {
+		t.Fatal(err)
+	}
+	return rate
+}
+
+func TestGainExp(t *testing.T) {
+	rate := mediumRate(t)
+
+	cases := []struct {
+		name   string
+		start  Pokemon
+		amount int
+		level  int
+		exp    int
+		gained int
+	}{
+		{name: "no level up", start: Pokemon{Level: 5, Exp: 125}, amount: 50, level: 5, exp: 175, gained: 0},
+		{name: "one level", start: Pokemon{Level: 5, Exp: 125}, amount: 91, level: 6, exp: 216, gained: 1},
+		{name: "several levels", start: Pokemon{Level: 5, Exp: 200}, amount: 400, level: 8, exp: 600, gained: 3},
+		// pokemon from old saves have a level but no exp
+		{name: "old save", start: Pokemon{Level: 5}, amount: 10, level: 5, exp: 135, gained: 0},
+		{name: "capped", start: Pokemon{Level: 9, Exp: 900}, amount: 5000, level: 10, exp: 1000, gained: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := c.start
+			gained := p.GainExp(c.amount, rate)
+			if gained != c.gained || p.Level != c.level || p.Exp != c.exp {
+				t.Errorf("expected +%d to level %d with %d exp, got +%d to level %d with %d exp",
+					c.gained, c.level, c.exp, gained, p.Level, p.Exp)
+			}
+			if p.Friendship != c.start.Friendship+5*c.gained {
+				t.Errorf("expected friendship to grow by 5 a level, got %d", p.Friendship)
+			}
+			if p.GrowthRate != "medium" {
+				t.Errorf("expected growth rate to be set, got %q", p.GrowthRate)
+			}
+		})
+	}
+}
+
+func TestCalcStat(t *testing.T) {
+	// garchomp at level 78, the worked example from the games' stat
+	// formula without effort values or nature
+	cases := []struct {
+		stat     string
+		base     int
+		iv       int
+		level    int
+		expected int
+	}{
+		{stat: "hp", base: 108, iv: 24, level: 78, expected: 275},
+		{stat: "attack", base: 130, iv: 12, level: 78, expected: 217},
+		{stat: "speed", base: 102, iv: 5, level: 78, expected: 168},
+		{stat: "hp", base: 35, iv: 0, level: 1, expected: 11},
+	}
+
+	for _, c := range cases {
+		if actual := CalcStat(c.stat, c.base, c.iv, c.level); actual != c.expected {
+			t.Errorf("CalcStat(%s, %d, %d, %d) = %d, expected %d", c.stat, c.base, c.iv, c.level, actual, c.expected)
+		}
+	}
+}
+
+func TestExpYield(t *testing.T) {
+	if actual := ExpYield(112, 5); actual != 80 {
+		t.Errorf("expected 80 exp for a level 5 pikachu, got %d", actual)
+	}
+	if actual := ExpYield(0, 1); actual != 1 {
+		t.Errorf("expected at least 1 exp, got %d", actual)
+	}
+}
@@ -0,0 +1,135 @@
+package trainer
+
+import (
+	"cmp"
+	"slices"
+)
+
+// NationalDexSize is the number of species in the national Pokedex
+const NationalDexSize = 1025
+
+// DexEntry is what the Pokedex knows about a species. Being in the
+// Pokedex at all means the species was seen.
+type DexEntry struct {
+	Name string `json:"name"`
+	// Number is the national dex number, 0 when unknown
+	Number int  `json:"number"`
+	Caught bool `json:"caught"`
+}
+
+// generationEnds holds the last national dex number of each generation
+var generationEnds = []int{151, 251, 386, 493, 649, 721, 809, 905, NationalDexSize}
+
+// Generation returns the generation a national dex number was introduced
+// in, or 0 for numbers outside the national dex (such as alternate forms)
+func Generation(number int) int {
+	if number < 1 || number > NationalDexSize {
+		return 0
+	}
+	gen, _ := slices.BinarySearch(generationEnds, number)
+	return gen + 1
+}
+
+// generationSize returns how many species a generation introduced
+func generationSize(gen int) int {
+	if gen == 1 {
+		return generationEnds[0]
+	}
+	return generationEnds[gen-1] - generationEnds[gen-2]
+}
+
+// See records that the trainer has seen a species. A number of 0 keeps
+// whatever number was known before.
+func (t *Trainer) See(name string, number int) {
+	if t.Pokedex == nil {
+		t.Pokedex = make(map[string]DexEntry)
+	}
+	entry := t.Pokedex[name]
+	entry.Name = name
+	if number != 0 {
+		entry.Number = number
+	}
+	t.Pokedex[name] = entry
+}
+
+// DexSort picks the order of DexEntries
+type DexSort int
+
+const (
+	SortByNumber DexSort = iota
+	SortByName
+)
+
+// DexEntries returns the Pokedex as a list. Sorting by number puts
+// entries without a known number last.
+func (t *Trainer) DexEntries(by DexSort) []DexEntry {
+	entries := make([]DexEntry, 0, len(t.Pokedex))
+	for _, e := range t.Pokedex {
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, func(a, b DexEntry) int {
+		if by == SortByNumber && a.Number != b.Number {
+			switch {
+			case a.Number == 0:
+				return 1
+			case b.Number == 0:
+				return -1
+			}
+			return cmp.Compare(a.Number, b.Number)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+// Completion counts seen and caught species out of Total. Generation is
+// 0 for the national dex as a whole.
+type Completion struct {
+	Generation int
+	Total      int
+	Seen       int
+	Caught     int
+}
+
+// SeenPercent returns the share of seen species, 0-100
+func (c Completion) SeenPercent() float64 {
+	return percent(c.Seen, c.Total)
+}
+
+// CaughtPercent returns the share of caught species, 0-100
+func (c Completion) CaughtPercent() float64 {
+	return percent(c.Caught, c.Total)
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// Completion returns the national dex completion followed by one entry
+// per generation. Only entries with a national dex number count.
+func (t *Trainer) Completion() (overall Completion, byGeneration []Completion) {
+	overall.Total = NationalDexSize
+	byGeneration = make([]Completion, len(generationEnds))
+	for i := range byGeneration {
+		byGeneration[i] = Completion{Generation: i + 1, Total: generationSize(i + 1)}
+	}
+
+	for _, e := range t.Pokedex {
+		gen := Generation(e.Number)
+		if gen == 0 {
+			continue
+		}
+		overall.Seen++
+		byGeneration[gen-1].Seen++
+		if e.Caught {
+			overall.Caught++
+			byGeneration[gen-1].Caught++
+		}
+	}
+
+	return overall, byGeneration
+}
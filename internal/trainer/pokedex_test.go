@@ -0,0 +1,89 @@
+package trainer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+func TestGeneration(t *testing.T) {
+	cases := []struct {
+		number   int
+		expected int
+	}{
+		{number: 0, expected: 0},
+		{number: 1, expected: 1},
+		{number: 151, expected: 1},
+		{number: 152, expected: 2},
+		{number: 387, expected: 4},
+		{number: 1025, expected: 9},
+		{number: 10034, expected: 0},
+	}
+
+	for _, c := range cases {
+		if actual := Generation(c.number); actual != c.expected {
+			t.Errorf("Generation(%d) = %d, expected %d", c.number, actual, c.expected)
+		}
+	}
+
+	total := 0
+	for gen := 1; gen <= len(generationEnds); gen++ {
+		total += generationSize(gen)
+	}
+	if total != NationalDexSize {
+		t.Errorf("generation sizes add up to %d, expected %d", total, NationalDexSize)
+	}
+}
+
+func TestSeenAndCaught(t *testing.T) {
+	tr := New("ash")
+	tr.See("pidgey", 16)
+	tr.See("rattata", 0)
+	tr.See("rattata", 19)
+	tr.See("rattata", 0)
+	tr.See("chikorita", 152)
+	tr.See("pikachu-rock-star", 10080)
+	tr.RecordThrow(pokeapi.PokemonStats{ID: 25, Name: "pikachu"}, &Pokemon{})
+	tr.RecordThrow(pokeapi.PokemonStats{ID: 16, Name: "pidgey"}, nil)
+
+	if e := tr.Pokedex["rattata"]; e.Number != 19 || e.Caught {
+		t.Errorf("unexpected rattata entry: %+v", e)
+	}
+	if e := tr.Pokedex["pidgey"]; e.Caught {
+		t.Errorf("expected pidgey to only be seen: %+v", e)
+	}
+	if e := tr.Pokedex["pikachu"]; !e.Caught || e.Number != 25 {
+		t.Errorf("expected pikachu to be caught: %+v", e)
+	}
+
+	names := func(entries []DexEntry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name)
+		}
+		return out
+	}
+	byNumber := names(tr.DexEntries(SortByNumber))
+	if !slices.Equal(byNumber, []string{"pidgey", "rattata", "pikachu", "chikorita", "pikachu-rock-star"}) {
+		t.Errorf("unexpected order by number: %v", byNumber)
+	}
+	byName := names(tr.DexEntries(SortByName))
+	if !slices.Equal(byName, []string{"chikorita", "pidgey", "pikachu", "pikachu-rock-star", "rattata"}) {
+		t.Errorf("unexpected order by name: %v", byName)
+	}
+
+	overall, byGeneration := tr.Completion()
+	if overall != (Completion{Total: NationalDexSize, Seen: 4, Caught: 1}) {
+		t.Errorf("unexpected overall completion: %+v", overall)
+	}
+	if byGeneration[0] != (Completion{Generation: 1, Total: 151, Seen: 3, Caught: 1}) {
+		t.Errorf("unexpected gen 1 completion: %+v", byGeneration[0])
+	}
+	if byGeneration[1] != (Completion{Generation: 2, Total: 100, Seen: 1, Caught: 0}) {
+		t.Errorf("unexpected gen 2 completion: %+v", byGeneration[1])
+	}
+	if got := byGeneration[1].SeenPercent(); got != 1 {
+		t.Errorf("expected 1%% of gen 2 seen, got %v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package trainer
+
+import (
+	"time"
+)
+
+// StatNames are the six stats every pokemon has, in PokeAPI's naming
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const (
+	// MaxIV is the highest individual value a stat can roll
+	MaxIV = 31
+	// DefaultLevel is used for pokemon caught outside of a known encounter
+	DefaultLevel = 5
+	// MaxFriendship is the highest friendship a pokemon can have
+	MaxFriendship = 255
+)
+
+// Pokemon is one caught pokemon. The same species can be caught any
+// number of times; each catch gets its own ID.
+type Pokemon struct {
+	ID       int       `json:"id"`
+	Species  string    `json:"species"`
+	Nickname string    `json:"nickname,omitempty"`
+	CaughtAt time.Time `json:"caught_at"`
+	Location string    `json:"location,omitempty"`
+	Level    int       `json:"level"`
+	// Exp is the total experience, GrowthRate the name of the curve
+	// that turns it into a level
+	Exp        int    `json:"exp"`
+	GrowthRate string `json:"growth_rate,omitempty"`
+	// Friendship starts at the species' base happiness and grows
+	// as the pokemon levels up
+	Friendship int `json:"friendship"`
+	// IVs are the individual values keyed by stat name, 0 to MaxIV
+	IVs map[string]int `json:"ivs"`
+}
+
+// DisplayName is the nickname if there is one, otherwise the species
+func (p *Pokemon) DisplayName() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Species
+}
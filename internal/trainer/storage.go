@@ -0,0 +1,163 @@
+package trainer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	// PartySize is how many pokemon a trainer can carry
+	PartySize = 6
+	// BoxSize is how many pokemon fit in one PC box
+	BoxSize = 30
+)
+
+var (
+	ErrPartyFull       = errors.New("trainer: party is full")
+	ErrLastPartyMember = errors.New("trainer: can't leave the party empty")
+	ErrNotInParty      = errors.New("trainer: pokemon is not in the party")
+	ErrNotInBox        = errors.New("trainer: pokemon is not in a box")
+	ErrNoSuchSlot      = errors.New("trainer: no such party slot")
+	ErrNoSuchBox       = errors.New("trainer: no such box")
+)
+
+// Place says where a caught pokemon is kept. Box and Slot count from 1;
+// Box is 0 for pokemon in the party.
+type Place struct {
+	Box  int
+	Slot int
+}
+
+// InParty reports whether the place is a party slot
+func (p Place) InParty() bool {
+	return p.Box == 0
+}
+
+func (p Place) String() string {
+	if p.InParty() {
+		return fmt.Sprintf("party slot %d", p.Slot)
+	}
+	return fmt.Sprintf("box %d slot %d", p.Box, p.Slot)
+}
+
+// store puts a newly caught pokemon in the party, or in the first box
+// with room once the party is full
+func (t *Trainer) store(id int) Place {
+	if len(t.Party) < PartySize {
+		t.Party = append(t.Party, id)
+		return Place{Slot: len(t.Party)}
+	}
+	return t.storeInBox(id)
+}
+
+func (t *Trainer) storeInBox(id int) Place {
+	for i, box := range t.Boxes {
+		if len(box) < BoxSize {
+			t.Boxes[i] = append(box, id)
+			return Place{Box: i + 1, Slot: len(t.Boxes[i])}
+		}
+	}
+	t.Boxes = append(t.Boxes, []int{id})
+	return Place{Box: len(t.Boxes), Slot: 1}
+}
+
+// unstore takes a pokemon out of wherever it is kept
+func (t *Trainer) unstore(id int) {
+	t.Party = slices.DeleteFunc(t.Party, func(other int) bool { return other == id })
+	for i := range t.Boxes {
+		t.Boxes[i] = slices.DeleteFunc(t.Boxes[i], func(other int) bool { return other == id })
+	}
+}
+
+// Where returns where the pokemon with the given ID is kept
+func (t *Trainer) Where(id int) (Place, error) {
+	if idx := slices.Index(t.Party, id); idx >= 0 {
+		return Place{Slot: idx + 1}, nil
+	}
+	for i, box := range t.Boxes {
+		if idx := slices.Index(box, id); idx >= 0 {
+			return Place{Box: i + 1, Slot: idx + 1}, nil
+		}
+	}
+	return Place{}, fmt.Errorf("%w: #%d", ErrNoSuchPokemon, id)
+}
+
+// boxed counts the pokemon kept in boxes
+func (t *Trainer) boxed() int {
+	n := 0
+	for _, box := range t.Boxes {
+		n += len(box)
+	}
+	return n
+}
+
+// PartyPokemon returns the pokemon in the party in slot order
+func (t *Trainer) PartyPokemon() []*Pokemon {
+	return t.lookup(t.Party)
+}
+
+// Box returns the pokemon in box n (counting from 1) in slot order
+func (t *Trainer) Box(n int) ([]*Pokemon, error) {
+	if n < 1 || n > max(len(t.Boxes), 1) {
+		return nil, fmt.Errorf("%w: %d", ErrNoSuchBox, n)
+	}
+	if n > len(t.Boxes) {
+		// box 1 always exists, even if nothing was ever put in it
+		return nil, nil
+	}
+	return t.lookup(t.Boxes[n-1]), nil
+}
+
+func (t *Trainer) lookup(ids []int) []*Pokemon {
+	found := make([]*Pokemon, 0, len(ids))
+	for _, id := range ids {
+		if p, err := t.Find(id); err == nil {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// SwapParty swaps the pokemon in two party slots (counting from 1)
+func (t *Trainer) SwapParty(a, b int) error {
+	for _, slot := range []int{a, b} {
+		if slot < 1 || slot > len(t.Party) {
+			return fmt.Errorf("%w: %d", ErrNoSuchSlot, slot)
+		}
+	}
+	t.Party[a-1], t.Party[b-1] = t.Party[b-1], t.Party[a-1]
+	return nil
+}
+
+// Deposit moves a pokemon from the party to the first box with room
+func (t *Trainer) Deposit(id int) (Place, error) {
+	if _, err := t.Find(id); err != nil {
+		return Place{}, err
+	}
+	if !slices.Contains(t.Party, id) {
+		return Place{}, fmt.Errorf("%w: #%d", ErrNotInParty, id)
+	}
+	if len(t.Party) == 1 {
+		return Place{}, ErrLastPartyMember
+	}
+	t.unstore(id)
+	return t.storeInBox(id), nil
+}
+
+// Withdraw moves a pokemon from its box to the end of the party
+func (t *Trainer) Withdraw(id int) (Place, error) {
+	place, err := t.Where(id)
+	if err != nil {
+		return Place{}, err
+	}
+	if place.InParty() {
+		return Place{}, fmt.Errorf("%w: #%d", ErrNotInBox, id)
+	}
+	if len(t.Party) >= PartySize {
+		return Place{}, ErrPartyFull
+	}
+	t.unstore(id)
+	t.Party = append(t.Party, id)
+	return Place{Slot: len(t.Party)}, nil
+}
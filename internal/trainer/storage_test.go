@@ -0,0 +1,157 @@
+package trainer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// newTrainerWith returns a trainer that caught n pokemon, with ids 1 to n
+func newTrainerWith(n int) *Trainer {
+	tr := New("ash")
+	for i := 0; i < n; i++ {
+		tr.RecordThrow(pokeapi.PokemonStats{Name: fmt.Sprintf("mon-%d", i)}, &Pokemon{})
+	}
+	return tr
+}
+
+func TestCatchOverflowsToBoxes(t *testing.T) {
+	tr := New("ash")
+	var places []Place
+	for i := 0; i < PartySize+BoxSize+1; i++ {
+		places = append(places, tr.RecordThrow(pokeapi.PokemonStats{Name: "magikarp"}, &Pokemon{}))
+	}
+
+	cases := []struct {
+		idx      int
+		expected Place
+	}{
+		{idx: 0, expected: Place{Slot: 1}},
+		{idx: PartySize - 1, expected: Place{Slot: PartySize}},
+		{idx: PartySize, expected: Place{Box: 1, Slot: 1}},
+		{idx: PartySize + BoxSize - 1, expected: Place{Box: 1, Slot: BoxSize}},
+		{idx: PartySize + BoxSize, expected: Place{Box: 2, Slot: 1}},
+	}
+	for _, c := range cases {
+		if places[c.idx] != c.expected {
+			t.Errorf("catch %d: expected %v, got %v", c.idx, c.expected, places[c.idx])
+		}
+		where, err := tr.Where(c.idx + 1)
+		if err != nil || where != c.expected {
+			t.Errorf("Where(%d) = %v, %v; expected %v", c.idx+1, where, err, c.expected)
+		}
+	}
+
+	if place := tr.RecordThrow(pokeapi.PokemonStats{Name: "magikarp"}, nil); place != (Place{}) {
+		t.Errorf("expected no place for an escaped pokemon, got %v", place)
+	}
+}
+
+func TestSwapParty(t *testing.T) {
+	tr := newTrainerWith(3)
+	if err := tr.SwapParty(1, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(tr.Party, []int{3, 2, 1}) {
+		t.Errorf("unexpected party after swap: %v", tr.Party)
+	}
+	if err := tr.SwapParty(1, 4); !errors.Is(err, ErrNoSuchSlot) {
+		t.Errorf("expected ErrNoSuchSlot, got %v", err)
+	}
+	if err := tr.SwapParty(0, 1); !errors.Is(err, ErrNoSuchSlot) {
+		t.Errorf("expected ErrNoSuchSlot, got %v", err)
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	tr := newTrainerWith(PartySize + 1)
+
+	if _, err := tr.Withdraw(7); !errors.Is(err, ErrPartyFull) {
+		t.Errorf("expected ErrPartyFull, got %v", err)
+	}
+	if _, err := tr.Deposit(7); !errors.Is(err, ErrNotInParty) {
+		t.Errorf("expected ErrNotInParty, got %v", err)
+	}
+	if _, err := tr.Withdraw(1); !errors.Is(err, ErrNotInBox) {
+		t.Errorf("expected ErrNotInBox, got %v", err)
+	}
+
+	place, err := tr.Deposit(2)
+	if err != nil || place != (Place{Box: 1, Slot: 2}) {
+		t.Errorf("expected #2 in box 1 slot 2, got %v, %v", place, err)
+	}
+	if !slices.Equal(tr.Party, []int{1, 3, 4, 5, 6}) {
+		t.Errorf("unexpected party after deposit: %v", tr.Party)
+	}
+
+	place, err = tr.Withdraw(7)
+	if err != nil || place != (Place{Slot: 6}) {
+		t.Errorf("expected #7 in party slot 6, got %v, %v", place, err)
+	}
+	box, _ := tr.Box(1)
+	if len(box) != 1 || box[0].ID != 2 {
+		t.Errorf("expected only #2 in box 1, got %v", box)
+	}
+
+	for _, id := range []int{1, 3, 4, 5, 6} {
+		if _, err := tr.Deposit(id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := tr.Deposit(7); !errors.Is(err, ErrLastPartyMember) {
+		t.Errorf("expected ErrLastPartyMember, got %v", err)
+	}
+	if _, err := tr.Deposit(99); !errors.Is(err, ErrNoSuchPokemon) {
+		t.Errorf("expected ErrNoSuchPokemon, got %v", err)
+	}
+}
+
+func TestBox(t *testing.T) {
+	tr := New("ash")
+	if box, err := tr.Box(1); err != nil || len(box) != 0 {
+		t.Errorf("expected an empty box 1, got %v, %v", box, err)
+	}
+	if _, err := tr.Box(2); !errors.Is(err, ErrNoSuchBox) {
+		t.Errorf("expected ErrNoSuchBox, got %v", err)
+	}
+}
+
+func TestReleaseFreesStorage(t *testing.T) {
+	tr := newTrainerWith(PartySize + 1)
+	if _, err := tr.Release(3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tr.Release(7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if slices.Contains(tr.Party, 3) || len(tr.Boxes[0]) != 0 {
+		t.Errorf("expected released pokemon to leave storage: party %v, boxes %v", tr.Party, tr.Boxes)
+	}
+}
+
+func TestReleaseLastPartyMember(t *testing.T) {
+	tr := newTrainerWith(2)
+	if _, err := tr.Deposit(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tr.Release(1); !errors.Is(err, ErrLastPartyMember) {
+		t.Errorf("expected ErrLastPartyMember, got %v", err)
+	}
+	if !slices.Equal(tr.Party, []int{1}) || len(tr.Pokemon) != 2 {
+		t.Errorf("expected a refused release to change nothing: party %v, %d pokemon", tr.Party, len(tr.Pokemon))
+	}
+
+	// with nothing boxed the last pokemon can go
+	if _, err := tr.Release(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tr.Release(1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(tr.Party) != 0 || len(tr.Pokemon) != 0 {
+		t.Errorf("expected no pokemon left: party %v, %d pokemon", tr.Party, len(tr.Pokemon))
+	}
+}
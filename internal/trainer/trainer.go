@@ -0,0 +1,118 @@
+package trainer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+var ErrNoSuchPokemon = errors.New("trainer: no caught pokemon with that id")
+
+// Stats are the running totals shown on a trainer's profile
+type Stats struct {
+	BallsThrown int `json:"balls_thrown"`
+	Caught      int `json:"caught"`
+	Escaped     int `json:"escaped"`
+}
+
+// Trainer is one player's progress
+type Trainer struct {
+	Name  string `json:"name"`
+	Stats Stats  `json:"stats"`
+	// Species holds the data of every species ever caught, keyed by
+	// pokemon name
+	Species map[string]pokeapi.PokemonSummary `json:"species"`
+	// Pokedex records every species seen or caught
+	Pokedex map[string]DexEntry `json:"pokedex"`
+	// Pokemon are the individual pokemon the trainer owns
+	Pokemon []*Pokemon `json:"pokemon"`
+	// NextID is the ID given to the next caught pokemon
+	NextID int `json:"next_id"`
+	// Party holds the IDs of up to PartySize pokemon carried around,
+	// everything else lives in Boxes of up to BoxSize
+	Party []int   `json:"party"`
+	Boxes [][]int `json:"boxes"`
+}
+
+// New creates a trainer with an empty Pokedex
+func New(name string) *Trainer {
+	return &Trainer{
+		Name:    name,
+		Species: make(map[string]pokeapi.PokemonSummary),
+		Pokedex: make(map[string]DexEntry),
+	}
+}
+
+// RecordThrow updates the stats after a ball was thrown. The species is
+// seen either way; on a successful catch it is registered as caught and
+// caught is added to the trainer's pokemon with the next free ID, going
+// to the party if there is room and to a box otherwise.
+func (t *Trainer) RecordThrow(species pokeapi.PokemonStats, caught *Pokemon) Place {
+	t.Stats.BallsThrown++
+	t.See(species.Name, species.ID)
+	if caught == nil {
+		t.Stats.Escaped++
+		return Place{}
+	}
+	t.Stats.Caught++
+	t.registerCaught(species)
+
+	t.NextID = max(t.NextID, 1)
+	caught.ID = t.NextID
+	caught.Species = species.Name
+	t.NextID++
+	t.Pokemon = append(t.Pokemon, caught)
+	return t.store(caught.ID)
+}
+
+// registerCaught keeps the species data and marks it caught in the
+// Pokedex; the species must have been seen already
+func (t *Trainer) registerCaught(species pokeapi.PokemonStats) {
+	if t.Species == nil {
+		t.Species = make(map[string]pokeapi.PokemonSummary)
+	}
+	t.Species[species.Name] = species.Summary()
+	entry := t.Pokedex[species.Name]
+	entry.Caught = true
+	t.Pokedex[species.Name] = entry
+}
+
+// Find returns the caught pokemon with the given ID
+func (t *Trainer) Find(id int) (*Pokemon, error) {
+	for _, p := range t.Pokemon {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: #%d", ErrNoSuchPokemon, id)
+}
+
+// OfSpecies returns every caught pokemon of a species, oldest first
+func (t *Trainer) OfSpecies(species string) []*Pokemon {
+	var found []*Pokemon
+	for _, p := range t.Pokemon {
+		if p.Species == species {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// Release lets a caught pokemon go. The species stays in the Pokedex.
+// The last pokemon in the party can only be released when the boxes are
+// empty too, so the party is never left empty while pokemon are stored.
+func (t *Trainer) Release(id int) (*Pokemon, error) {
+	idx := slices.IndexFunc(t.Pokemon, func(p *Pokemon) bool { return p.ID == id })
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: #%d", ErrNoSuchPokemon, id)
+	}
+	if slices.Equal(t.Party, []int{id}) && t.boxed() > 0 {
+		return nil, ErrLastPartyMember
+	}
+	p := t.Pokemon[idx]
+	t.Pokemon = slices.Delete(t.Pokemon, idx, idx+1)
+	t.unstore(id)
+	return p, nil
+}
@@ -0,0 +1,60 @@
+package trainer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+func TestRecordThrow(t *testing.T) {
+	tr := New("ash")
+	pikachu := pokeapi.PokemonStats{ID: 25, Name: "pikachu"}
+
+	tr.RecordThrow(pikachu, nil)
+	tr.RecordThrow(pikachu, &Pokemon{Level: 5})
+	tr.RecordThrow(pikachu, &Pokemon{Level: 7, Nickname: "sparky"})
+
+	if tr.Stats != (Stats{BallsThrown: 3, Caught: 2, Escaped: 1}) {
+		t.Errorf("unexpected stats: %+v", tr.Stats)
+	}
+	if len(tr.Species) != 1 {
+		t.Errorf("expected one species in the pokedex, got %d", len(tr.Species))
+	}
+
+	// a second pikachu must not overwrite the first
+	owned := tr.OfSpecies("pikachu")
+	if len(owned) != 2 || owned[0].ID != 1 || owned[1].ID != 2 {
+		t.Fatalf("expected two pikachu with ids 1 and 2, got %+v", owned)
+	}
+	if owned[1].DisplayName() != "sparky" || owned[0].DisplayName() != "pikachu" {
+		t.Errorf("unexpected display names: %s, %s", owned[0].DisplayName(), owned[1].DisplayName())
+	}
+}
+
+func TestRelease(t *testing.T) {
+	tr := New("ash")
+	for _, name := range []string{"bulbasaur", "charmander", "squirtle"} {
+		tr.RecordThrow(pokeapi.PokemonStats{Name: name}, &Pokemon{})
+	}
+
+	p, err := tr.Release(2)
+	if err != nil || p.Species != "charmander" {
+		t.Fatalf("expected to release charmander, got %+v, %v", p, err)
+	}
+	if _, err := tr.Find(2); !errors.Is(err, ErrNoSuchPokemon) {
+		t.Errorf("expected ErrNoSuchPokemon, got %v", err)
+	}
+	if _, err := tr.Release(2); !errors.Is(err, ErrNoSuchPokemon) {
+		t.Errorf("expected ErrNoSuchPokemon, got %v", err)
+	}
+	if _, ok := tr.Species["charmander"]; !ok {
+		t.Errorf("expected charmander to stay in the pokedex")
+	}
+
+	// ids are never reused
+	tr.RecordThrow(pokeapi.PokemonStats{Name: "pidgey"}, &Pokemon{})
+	if p, _ := tr.Find(4); p == nil || p.Species != "pidgey" {
+		t.Errorf("expected pidgey to get id 4, got %+v", tr.Pokemon)
+	}
+}
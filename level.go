@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// fetches the growth rate of a caught pokemon; pokemon from
+// old saves don't know theirs yet, so it comes from the species
+func growthRateOf(ctx context.Context, cfg *cmdConfig, p *trainer.Pokemon) (pokeapi.GrowthRate, error) {
+	name := p.GrowthRate
+	if name == "" {
+		species, err := cfg.client.GetPokemonSpecies(ctx, speciesOf(cfg, p))
+		if err != nil {
+			return pokeapi.GrowthRate{}, err
+		}
+		name = species.GrowthRate.Name
+	}
+	return cfg.client.GetGrowthRate(ctx, name)
+}
+
+// gives exp to one of the trainer's pokemon and announces level ups
+func gainExp(ctx context.Context, cfg *cmdConfig, p *trainer.Pokemon, amount int) error {
+	rate, err := growthRateOf(ctx, cfg, p)
+	if err != nil {
+		return err
+	}
+
+	if gained := p.GainExp(amount, rate); gained == 0 {
+		fmt.Printf("%s gained %d exp\n", p.DisplayName(), amount)
+		return nil
+	}
+	fmt.Printf("%s gained %d exp and grew to level %d!\n", p.DisplayName(), amount, p.Level)
+
+	_, err = tryEvolve(ctx, cfg, p, trainer.Trigger{Kind: trainer.TriggerLevelUp, Time: time.Now()})
+	return err
+}
+
+// returns the species name of a caught pokemon, which differs
+// from the pokemon name for alternate forms
+func speciesOf(cfg *cmdConfig, p *trainer.Pokemon) string {
+	if data, ok := cfg.trainer.Species[p.Species]; ok && data.Species.Name != "" {
+		return data.Species.Name
+	}
+	return p.Species
+}
@@ -2,33 +2,56 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand/v2"
 	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/pokecache"
+	"github.com/snyderg13/pokedex/internal/pokesave"
+	"github.com/snyderg13/pokedex/internal/trainer"
 )
 
-var Pokedex map[string]pokeapi.PokemonStats
-
-func initPokedex() {
-	Pokedex = make(map[string]pokeapi.PokemonStats)
-}
-
 type cmdConfig struct {
-	Next string
-	Prev string
+	Next    string
+	Prev    string
+	client  *pokeapi.Client
+	rng     randSource
+	store   *pokesave.Store
+	profile string
+	trainer *trainer.Trainer
+	// area is the location area explored last
+	area *pokeapi.LocationDetails
+	// chart is fetched the first time a command needs it
+	chart *pokeapi.TypeChart
+	// cache is the client's memory cache and tiered puts it in front of
+	// the disk tier, nil if there is none
+	cache  *pokecache.Cache
+	tiered *pokecache.TieredCache
 }
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*cmdConfig, ...string) error
+	callback    func(context.Context, *cmdConfig, ...string) error
+	// keepCase passes the arguments as typed instead of lowercased,
+	// for commands that take free text like a nickname
+	keepCase bool
 }
 
 var pokeCmds map[string]cliCommand
 
+var (
+	errNoSuchPokemon = errors.New("no such pokemon")
+	errNoSuchArea    = errors.New("no such location area")
+	errEmptyParty    = errors.New("you have no pokemon in your party")
+)
+
 func initCmds() {
 	pokeCmds = map[string]cliCommand{
 		"exit": {
@@ -57,18 +80,99 @@ func initCmds() {
 			callback:    commandExplore,
 		},
 		"catch": {
-			name:        "catch <pokemon_name>",
+			name:        "catch <pokemon_name> [ball]",
 			description: "Attempt to catch a pokemon",
 			callback:    commandCatch,
 		},
 		"inspect": {
-			name:        "inspect <pokemon_name>",
+			name:        "inspect <pokemon_name|#id>",
 			description: "Displays stats for a pokemon",
 			callback:    commandInspect,
 		},
+		"nickname": {
+			name:        "nickname <#id> <nickname>",
+			description: "Gives one of your pokemon a nickname",
+			callback:    commandNickname,
+			keepCase:    true,
+		},
+		"release": {
+			name:        "release <#id>",
+			description: "Releases one of your pokemon back into the wild",
+			callback:    commandRelease,
+		},
+		"party": {
+			name:        "party [swap <slot> <slot>]",
+			description: "Shows or reorders your party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box [n]",
+			description: "Shows the pokemon stored in a PC box",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit <#id>",
+			description: "Moves a pokemon from your party to the PC",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw <#id>",
+			description: "Moves a pokemon from the PC to your party",
+			callback:    commandWithdraw,
+		},
+		"battle": {
+			name:        "battle <pokemon_name> [level]",
+			description: "Battles a wild pokemon with the lead of your party",
+			callback:    commandBattle,
+		},
+		"evolve": {
+			name:        "evolve <#id> [item|trade [item]]",
+			description: "Evolves one of your pokemon if it meets the conditions",
+			callback:    commandEvolve,
+		},
+		"evolutions": {
+			name:        "evolutions <pokemon_name|#id>",
+			description: "Displays the evolution chain of a pokemon",
+			callback:    commandEvolutions,
+		},
+		"types": {
+			name:        "types <pokemon_name|#id|type[/type]>",
+			description: "Displays the weaknesses, resistances and immunities of a pokemon or type",
+			callback:    commandTypes,
+		},
+		"matchup": {
+			name:        "matchup <attacker> <defender>",
+			description: "Displays how effective a pokemon's types are against another's",
+			callback:    commandMatchup,
+		},
+		"moves": {
+			name:        "moves <pokemon_name|#id> [--version-group x] [--method level-up|machine|egg|tutor|all]",
+			description: "Displays the moves a pokemon learns, by level-up unless another method is given",
+			callback:    commandMoves,
+		},
+		"cache": {
+			name:        "cache [stats|keys|clear|evict <url>]",
+			description: "Shows or manages the cache of PokeAPI responses",
+			callback:    commandCache,
+		},
+		"save": {
+			name:        "save",
+			description: "Saves your Pokedex to disk",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			description: "Loads your Pokedex from disk",
+			callback:    commandLoad,
+		},
+		"profile": {
+			name:        "profile [new|list|switch|delete <name>]",
+			description: "Shows or manages trainer profiles",
+			callback:    commandProfile,
+		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "Displays stats for a pokemon",
+			name:        "pokedex [number|name]",
+			description: "Displays the pokemon you have seen and caught",
 			callback:    commandPokedex,
 		},
 	}
@@ -84,13 +188,21 @@ func cleanInput(text string) []string {
 	return strings.Fields(strings.ToLower(text))
 }
 
-func commandExit(cfg *cmdConfig, args ...string) error {
+// returns the arguments of cmd from the line the user typed
+func commandArgs(cmd cliCommand, line string) []string {
+	if cmd.keepCase {
+		return strings.Fields(line)[1:]
+	}
+	return cleanInput(line)[1:]
+}
+
+func commandExit(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(cfg *cmdConfig, args ...string) error {
+func commandHelp(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Printf("Usage:\n\n")
 
@@ -101,11 +213,10 @@ func commandHelp(cfg *cmdConfig, args ...string) error {
 	return nil
 }
 
-func commandMap(cfg *cmdConfig, args ...string) error {
+func commandMap(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	debug := false
 
-	var results pokeapi.LocAreaResp
-	results, err := results.DoGetData(cfg.Next)
+	results, err := cfg.client.ListLocationAreas(ctx, cfg.Next)
 	if err != nil {
 		return err
 	}
@@ -124,7 +235,7 @@ func commandMap(cfg *cmdConfig, args ...string) error {
 	return nil
 }
 
-func commandMapb(cfg *cmdConfig, args ...string) error {
+func commandMapb(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	if len(cfg.Prev) == 0 {
 		fmt.Println("You're on the first page")
 		return nil
@@ -132,8 +243,7 @@ func commandMapb(cfg *cmdConfig, args ...string) error {
 
 	debug := false
 
-	var results pokeapi.LocAreaResp
-	results, err := results.DoGetData(cfg.Prev)
+	results, err := cfg.client.ListLocationAreas(ctx, cfg.Prev)
 	if err != nil {
 		return err
 	}
@@ -153,7 +263,7 @@ func commandMapb(cfg *cmdConfig, args ...string) error {
 }
 
 // @TODO add test cases for different commands
-func commandExplore(cfg *cmdConfig, args ...string) error {
+func commandExplore(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	fmt.Println("len(args) = ", len(args))
 	fmt.Println("args = ", args)
 	if len(args) == 0 {
@@ -161,16 +271,19 @@ func commandExplore(cfg *cmdConfig, args ...string) error {
 	}
 	fmt.Printf("Exploring %s...\n", args[0])
 
-	var results pokeapi.LocationDetails
-	results, err := results.DoGetData(args[0])
-	if err != nil {
-		fmt.Println("Exp: get data ret: ", err)
+	results, err := cfg.client.GetLocationArea(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("%w: %s", errNoSuchArea, args[0])
+	} else if err != nil {
 		return err
 	}
 
 	for _, p := range results.PokemonList {
 		fmt.Println(p.Pokemon.Name)
+		cfg.trainer.See(p.Pokemon.Name, p.Pokemon.ID())
 	}
+	cfg.area = &results
+	autosave(cfg)
 
 	// note that cfg.next and cfg.prev are not updated
 	// since the user only chose to explore an area;
@@ -180,137 +293,164 @@ func commandExplore(cfg *cmdConfig, args ...string) error {
 	return nil
 }
 
-func commandCatch(cfg *cmdConfig, args ...string) error {
+func commandInspect(ctx context.Context, cfg *cmdConfig, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("no pokemon name provided")
 	}
-	catchDebug := false
+	inspectDebug := false
 	name := args[0]
-	fmt.Printf("Throwing a Pokeball at %s...\n", name)
-
-	randIntVal := int32(rand.Float32() * 100)
-	if catchDebug {
-		fmt.Printf("Random int val = %d == %v\n", randIntVal, randIntVal)
-	}
-
-	var results pokeapi.PokemonStats
-	results, err := results.DoGetData(name)
-	if err != nil {
-		fmt.Println("Exp: get data ret: ", err)
-		return err
-	}
-
-	base_exp := results.BaseExperience
-	if catchDebug {
-		fmt.Printf("%s base exp is %d\n", name, base_exp)
-	}
-
-	// @TODO figure out best way to use RNG with below catch chance
-	//       might need to revisit and/or chance chance percentages
-	catchSuccessful := false
-	if base_exp > 600 {
-		// 5 % chance to catch
-		if randIntVal >= 95 {
-			catchSuccessful = true
-		}
-	} else if base_exp > 500 {
-		// 7.5 % chance to catch
-		if randIntVal >= 92 {
-			catchSuccessful = true
-		}
-	} else if base_exp > 400 {
-		// 10 % chance to catch
-		if randIntVal >= 90 {
-			catchSuccessful = true
-		}
-	} else if base_exp > 200 {
-		// 15 % chance to catch
-		if randIntVal >= 85 {
-			catchSuccessful = true
-		}
-	} else if base_exp > 100 {
-		// 20 % chance to catch
-		if randIntVal >= 80 {
-			catchSuccessful = true
-		}
-	} else {
-		// 50 % chance to catch
-		if randIntVal >= 50 {
-			catchSuccessful = true
-		}
+	if inspectDebug {
+		fmt.Printf("Inspecting %s...\n", name)
 	}
 
-	if catchSuccessful {
-		fmt.Println(name, "was caught!")
-		// @TODO add captured pokemon to user's pokedex
-		Pokedex[name] = results
-		if catchDebug {
-			fmt.Println("User Pokedex = ", Pokedex)
+	var caught *trainer.Pokemon
+	if strings.HasPrefix(name, "#") {
+		p, err := findPokemon(cfg, name)
+		if err != nil {
+			return err
 		}
-
-		val, ok := Pokedex[name]
-		if !ok {
-			if catchDebug {
-				fmt.Println(name, "not in pokedex, adding")
-			}
-		} else {
-			if catchDebug {
-				fmt.Println(name, "already in pokedex")
-				fmt.Println("pokemon data = ", val)
-			}
+		rate, err := growthRateOf(ctx, cfg, p)
+		if err != nil {
+			return err
 		}
-	} else {
-		fmt.Println(name, "escaped!")
-	}
-
-	return nil
-}
-
-func commandInspect(cfg *cmdConfig, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("no pokemon name provided")
-	}
-	inspectDebug := false
-	name := args[0]
-	if inspectDebug {
-		fmt.Printf("Inspecting %s...\n", name)
+		printPokemon(cfg.trainer, p, rate)
+		caught = p
+		name = p.Species
 	}
 
-	stats, ok := Pokedex[name]
+	stats, ok := cfg.trainer.Species[name]
 	if !ok {
 		fmt.Println("you have not caught that pokemon")
 	} else {
 		fmt.Println("Name:", stats.Name)
 		fmt.Println("Height:", stats.Height)
 		fmt.Println("Weight:", stats.Weight)
-		fmt.Println("Stats:")
-		for _, v := range stats.Stats {
-			fmt.Printf("  -%s: %d\n", v.Stat.Name, v.BaseStat)
+		if caught != nil {
+			// a caught pokemon shows its real stats, a
+			// species only has base stats to show
+			current := caught.CurrentStats(stats)
+			fmt.Printf("Stats (lv %d):\n", caught.Level)
+			for _, v := range stats.Stats {
+				fmt.Printf("  -%s: %d\n", v.Stat.Name, current[v.Stat.Name])
+			}
+		} else {
+			fmt.Println("Stats:")
+			for _, v := range stats.Stats {
+				fmt.Printf("  -%s: %d\n", v.Stat.Name, v.BaseStat)
+			}
 		}
 		fmt.Println("Types:")
 		for _, v := range stats.Types {
 			fmt.Printf("  - %s\n", v.Type.Name)
 		}
+
+		species, err := cfg.client.GetPokemonSpecies(ctx, stats.Species.Name)
+		if err != nil {
+			return err
+		}
+		printSpecies(species)
+
+		if caught == nil {
+			printOwned(cfg.trainer, cfg.trainer.OfSpecies(name))
+		}
 	}
 
 	return nil
 }
 
-func commandPokedex(cfg *cmdConfig, args ...string) error {
-	fmt.Println("Your Pokedex:")
-	for k := range Pokedex {
-		fmt.Printf(" - %s\n", k)
+// prints the species level data shown by inspect
+func printSpecies(species pokeapi.PokemonSpecies) {
+	if genus := species.Genus(pokeapi.DefaultLanguage); genus != "" {
+		fmt.Println("Genus:", genus)
 	}
-	return nil
+	switch {
+	case species.IsMythical:
+		fmt.Println("Mythical: yes")
+	case species.IsLegendary:
+		fmt.Println("Legendary: yes")
+	default:
+		fmt.Println("Legendary: no")
+	}
+	if text := species.FlavorText(pokeapi.DefaultLanguage); text != "" {
+		fmt.Println("Pokedex entry:")
+		fmt.Printf("  %s\n", text)
+	}
+}
+
+// runs a single command with a context that is canceled
+// when the user hits Ctrl-C, so only the running command
+// is aborted instead of the whole process
+func runCommand(cmd cliCommand, cfg *cmdConfig, args ...string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return cmd.callback(ctx, cfg, args...)
+}
+
+// turns an error returned by a command into
+// a message that makes sense to the user
+func friendlyError(err error) string {
+	var statusErr *pokeapi.StatusError
+	switch {
+	case errors.Is(err, errNoSuchPokemon), errors.Is(err, errNoSuchArea):
+		return err.Error()
+	case errors.Is(err, pokeapi.ErrNotFound):
+		return "PokeAPI has no data for that"
+	case errors.Is(err, pokeapi.ErrRateLimited):
+		return "PokeAPI is rate limiting us, try again in a moment"
+	case errors.Is(err, pokeapi.ErrDecode):
+		return "PokeAPI sent a response we couldn't understand"
+	case errors.As(err, &statusErr):
+		return fmt.Sprintf("PokeAPI is having trouble right now (status %d)", statusErr.StatusCode)
+	}
+	return err.Error()
 }
 
 func main() {
+	defaultDataDir, err := pokesave.DefaultDir()
+	if err != nil {
+		defaultDataDir = "."
+	}
+	defaultCacheDir, err := pokecache.DefaultDir()
+	if err != nil {
+		defaultCacheDir = ""
+	}
+	apiURL := flag.String("api", pokeapi.DefaultBaseURL, "base URL of the PokeAPI server")
+	cacheDir := flag.String("cache-dir", defaultCacheDir, "directory PokeAPI responses are cached in (empty: memory only)")
+	dataDir := flag.String("data-dir", defaultDataDir, "directory the Pokedex is saved in")
+	profile := flag.String("profile", "", "trainer profile to play as (default: the last one used)")
+	flag.Parse()
+
+	store, err := pokesave.NewStore(*dataDir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if *profile == "" {
+		*profile = store.Active()
+	}
+	if err := store.SetActive(*profile); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	var line string
 	var words []string
-	worldCfg := cmdConfig{}
+	memoryCache, tieredCache := newCache(*cacheDir)
+	worldCfg := cmdConfig{
+		client:  pokeapi.NewClient(pokeapi.WithBaseURL(*apiURL), pokeapi.WithCache(clientCache(memoryCache, tieredCache))),
+		rng:     rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		store:   store,
+		profile: *profile,
+		cache:   memoryCache,
+		tiered:  tieredCache,
+	}
 	initCmds()
-	pokeapi.Init()
-	initPokedex()
+	worldCfg.trainer, err = loadTrainer(&worldCfg, *profile)
+	if err != nil {
+		fmt.Println("warning: could not load saved Pokedex:", err)
+		worldCfg.trainer = trainer.New(*profile)
+	}
 	mainDebug := false
 	inputScanner := bufio.NewScanner(os.Stdin)
 
@@ -339,14 +479,13 @@ func main() {
 			}
 
 			command := words[0]
-			args := words[1:]
 			if cmd, ok := pokeCmds[command]; !ok {
 				fmt.Printf("Unknown command: %s\n", command)
-			} else if err := cmd.callback(&worldCfg, args...); err != nil {
-				// @TODO: not sure if below is the best way to do this
-				//        it looks gross and is most likely not something
-				//        that should be delayed to the user
-				fmt.Println(fmt.Errorf("command \"%s\" returned error \"%w\"", cmd.name, err))
+			} else if err := runCommand(cmd, &worldCfg, commandArgs(cmd, line)...); errors.Is(err, context.Canceled) {
+				fmt.Println()
+				fmt.Printf("command \"%s\" canceled\n", cmd.name)
+			} else if err != nil {
+				fmt.Println(friendlyError(err))
 			} else {
 				// @TODO: other logic to be added if needed
 				//        intentionally empty for now on purpose
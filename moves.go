@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// returns the data of a pokemon named by a command argument, either one
+// of the trainer's pokemon (#id) or any pokemon name
+func pokemonData(ctx context.Context, cfg *cmdConfig, arg string) (pokeapi.PokemonStats, error) {
+	if strings.HasPrefix(arg, "#") {
+		p, err := findPokemon(cfg, arg)
+		if err != nil {
+			return pokeapi.PokemonStats{}, err
+		}
+		// the saved species data has no moves, fetch all of it
+		return cfg.client.GetPokemon(ctx, p.Species)
+	}
+
+	stats, err := cfg.client.GetPokemon(ctx, arg)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return stats, fmt.Errorf("%w: %s", errNoSuchPokemon, arg)
+	}
+	return stats, err
+}
+
+// parses the options of the moves command; either form of
+// --method level-up and --method=level-up works
+func parseMovesArgs(args []string) (versionGroup, method string, err error) {
+	method = pokeapi.LearnLevelUp
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", "", fmt.Errorf("missing value for %s", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "--version-group":
+			versionGroup = value
+		case "--method":
+			method = value
+		default:
+			return "", "", fmt.Errorf("unknown option %s", name)
+		}
+	}
+
+	valid := []string{pokeapi.LearnLevelUp, pokeapi.LearnMachine, pokeapi.LearnEgg, pokeapi.LearnTutor}
+	switch {
+	case method == "all":
+		method = ""
+	case !slices.Contains(valid, method):
+		return "", "", fmt.Errorf("unknown method %q, expected one of %s or all", method, strings.Join(valid, ", "))
+	}
+	return versionGroup, method, nil
+}
+
+func commandMoves(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <pokemon_name|#id> [--version-group x] [--method level-up|machine|egg|tutor|all]")
+	}
+	versionGroup, method, err := parseMovesArgs(args[1:])
+	if err != nil {
+		return err
+	}
+	stats, err := pokemonData(ctx, cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	if versionGroup == "" {
+		versionGroup = stats.LatestVersionGroup()
+	}
+	learnset := stats.Learnset(versionGroup, method)
+	if len(learnset) == 0 {
+		fmt.Printf("%s learns no moves that way in %s\n", stats.Name, versionGroup)
+		return nil
+	}
+
+	names := make([]string, len(learnset))
+	for i, l := range learnset {
+		names[i] = l.Name
+	}
+	moves, err := cfg.client.GetMoves(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s learns in %s:\n", stats.Name, versionGroup)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  LEARNED\tMOVE\tTYPE\tCLASS\tPOWER\tACC\tPP")
+	for i, l := range learnset {
+		m := moves[i]
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			learnedAt(l), m.Name, m.Type.Name, m.DamageClass.Name, orDash(m.Power), orDash(m.Accuracy), m.PP)
+	}
+	return w.Flush()
+}
+
+// describes when a move is learned
+func learnedAt(l pokeapi.LearnedMove) string {
+	switch l.Method {
+	case pokeapi.LearnLevelUp:
+		return "lv " + strconv.Itoa(l.Level)
+	case pokeapi.LearnMachine:
+		return "TM"
+	}
+	return l.Method
+}
+
+// shows PokeAPI's null power and accuracy as a dash
+func orDash(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.Itoa(n)
+}
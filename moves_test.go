@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+func TestParseMovesArgs(t *testing.T) {
+	cases := []struct {
+		args         []string
+		versionGroup string
+		method       string
+		fails        bool
+	}{
+		{args: nil, method: pokeapi.LearnLevelUp},
+		{args: []string{"--method", "machine"}, method: pokeapi.LearnMachine},
+		{args: []string{"--method=egg", "--version-group", "x-y"}, versionGroup: "x-y", method: pokeapi.LearnEgg},
+		{args: []string{"--version-group=red-blue", "--method", "all"}, versionGroup: "red-blue", method: ""},
+		{args: []string{"--method", "sketch"}, fails: true},
+		{args: []string{"--method"}, fails: true},
+		{args: []string{"--level", "5"}, fails: true},
+	}
+
+	for _, c := range cases {
+		versionGroup, method, err := parseMovesArgs(c.args)
+		if c.fails {
+			if err == nil {
+				t.Errorf("%v: expected an error", c.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", c.args, err)
+			continue
+		}
+		if versionGroup != c.versionGroup || method != c.method {
+			t.Errorf("%v: expected %q %q, got %q %q", c.args, c.versionGroup, c.method, versionGroup, method)
+		}
+	}
+}
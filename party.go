@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func commandParty(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) > 0 {
+		if args[0] != "swap" {
+			return fmt.Errorf("unknown party command %q, expected swap", args[0])
+		}
+		if len(args) < 3 {
+			return fmt.Errorf("not enough args, expected party swap <slot> <slot>")
+		}
+		a, errA := strconv.Atoi(args[1])
+		b, errB := strconv.Atoi(args[2])
+		if errA != nil || errB != nil {
+			return fmt.Errorf("party slots are numbers from 1 to %d", trainer.PartySize)
+		}
+		if err := cfg.trainer.SwapParty(a, b); err != nil {
+			return err
+		}
+		autosave(cfg)
+	}
+
+	party := cfg.trainer.PartyPokemon()
+	fmt.Printf("Your party (%d/%d):\n", len(party), trainer.PartySize)
+	for i, p := range party {
+		fmt.Printf(" %d. #%d %s (lv %d)\n", i+1, p.ID, p.DisplayName(), p.Level)
+	}
+	return nil
+}
+
+func commandBox(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	n := 1
+	if len(args) > 0 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("%q is not a box number", args[0])
+		}
+	}
+
+	box, err := cfg.trainer.Box(n)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Box %d (%d/%d):\n", n, len(box), trainer.BoxSize)
+	for i, p := range box {
+		fmt.Printf(" %d. #%d %s (lv %d)\n", i+1, p.ID, p.DisplayName(), p.Level)
+	}
+	if boxes := len(cfg.trainer.Boxes); boxes > 1 {
+		fmt.Printf("You have %d boxes\n", boxes)
+	}
+	return nil
+}
+
+func commandDeposit(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <#id>")
+	}
+	p, err := findPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	place, err := cfg.trainer.Deposit(p.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s was sent to %s\n", p.ID, p.DisplayName(), place)
+	autosave(cfg)
+	return nil
+}
+
+func commandWithdraw(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <#id>")
+	}
+	p, err := findPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	place, err := cfg.trainer.Withdraw(p.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s joined your party in slot %d\n", p.ID, p.DisplayName(), place.Slot)
+	autosave(cfg)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func commandPokedex(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	sortBy := trainer.SortByNumber
+	if len(args) > 0 {
+		switch args[0] {
+		case "number":
+		case "name":
+			sortBy = trainer.SortByName
+		default:
+			return fmt.Errorf("unknown sort order %q, expected number or name", args[0])
+		}
+	}
+
+	entries := cfg.trainer.DexEntries(sortBy)
+	fmt.Println("Your Pokedex:")
+	for _, e := range entries {
+		fmt.Printf(" %s %s %s", dexNumber(e.Number), dexMarker(e), e.Name)
+		if owned := len(cfg.trainer.OfSpecies(e.Name)); owned > 1 {
+			fmt.Printf(" x%d", owned)
+		}
+		fmt.Println()
+	}
+	if len(entries) == 0 {
+		fmt.Println(" nothing yet, go explore!")
+	}
+
+	overall, byGeneration := cfg.trainer.Completion()
+	fmt.Println()
+	fmt.Printf("National: %s\n", formatCompletion(overall))
+	for _, c := range byGeneration {
+		if c.Seen > 0 {
+			fmt.Printf("Gen %d: %s\n", c.Generation, formatCompletion(c))
+		}
+	}
+	return nil
+}
+
+func dexNumber(n int) string {
+	if n == 0 {
+		return "#???"
+	}
+	return fmt.Sprintf("#%03d", n)
+}
+
+// marks whether a species was caught or only seen
+func dexMarker(e trainer.DexEntry) string {
+	if e.Caught {
+		return "(C)"
+	}
+	return "(S)"
+}
+
+func formatCompletion(c trainer.Completion) string {
+	return fmt.Sprintf("seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)",
+		c.Seen, c.Total, c.SeenPercent(), c.Caught, c.Total, c.CaughtPercent())
+}
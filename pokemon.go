@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// looks up one of the trainer's pokemon by an id
+// argument such as "#3" (the # is optional)
+func findPokemon(cfg *cmdConfig, arg string) (*trainer.Pokemon, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a pokemon id, expected something like #3", arg)
+	}
+	return cfg.trainer.Find(id)
+}
+
+// prints what sets one caught pokemon apart from others of its species
+func printPokemon(t *trainer.Trainer, p *trainer.Pokemon, rate pokeapi.GrowthRate) {
+	fmt.Printf("#%d %s\n", p.ID, p.DisplayName())
+	if p.Nickname != "" {
+		fmt.Println("Species:", p.Species)
+	}
+	if place, err := t.Where(p.ID); err == nil {
+		fmt.Println("Kept in:", place)
+	}
+	fmt.Println("Level:", p.Level)
+	exp := max(p.Exp, rate.ExperienceFor(p.Level))
+	if p.Level < min(trainer.MaxLevel, rate.MaxLevel()) {
+		fmt.Printf("Exp: %d (%d to next level)\n", exp, rate.ExperienceFor(p.Level+1)-exp)
+	} else {
+		fmt.Println("Exp:", exp)
+	}
+	fmt.Println("Friendship:", p.Friendship)
+	fmt.Println("Caught:", p.CaughtAt.Format("2006-01-02 15:04"))
+	if p.Location != "" {
+		fmt.Println("Caught in:", p.Location)
+	}
+	fmt.Println("IVs:")
+	for _, stat := range trainer.StatNames {
+		fmt.Printf("  -%s: %d\n", stat, p.IVs[stat])
+	}
+}
+
+// prints the ids of the trainer's pokemon of one species
+func printOwned(t *trainer.Trainer, owned []*trainer.Pokemon) {
+	if len(owned) == 0 {
+		return
+	}
+	fmt.Println("Yours:")
+	for _, p := range owned {
+		fmt.Printf("  - #%d %s (lv %d)", p.ID, p.DisplayName(), p.Level)
+		if place, err := t.Where(p.ID); err == nil {
+			fmt.Printf(" in %s", place)
+		}
+		fmt.Println()
+	}
+}
+
+func commandNickname(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("not enough args, expected <#id> <nickname>")
+	}
+	p, err := findPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	p.Nickname = strings.Join(args[1:], " ")
+	fmt.Printf("#%d %s is now called %s\n", p.ID, p.Species, p.Nickname)
+	autosave(cfg)
+	return nil
+}
+
+func commandRelease(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <#id>")
+	}
+	p, err := findPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	if _, err := cfg.trainer.Release(p.ID); err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s was released. Bye, %s!\n", p.ID, p.Species, p.DisplayName())
+	autosave(cfg)
+	return nil
+}
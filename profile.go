@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/snyderg13/pokedex/internal/pokesave"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func commandProfile(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		printTrainer(cfg.trainer)
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return profileList(cfg)
+	case "new", "switch", "delete":
+		if len(args) < 2 {
+			return fmt.Errorf("not enough args, expected profile %s <name>", args[0])
+		}
+	default:
+		return fmt.Errorf("unknown profile command %q, expected new|list|switch|delete", args[0])
+	}
+
+	name := args[1]
+	switch args[0] {
+	case "new":
+		return profileNew(cfg, name)
+	case "switch":
+		return profileSwitch(cfg, name)
+	default:
+		if err := cfg.store.Delete(name); err != nil {
+			return err
+		}
+		fmt.Println("Deleted profile", name)
+		return nil
+	}
+}
+
+func printTrainer(t *trainer.Trainer) {
+	fmt.Println("Trainer:", t.Name)
+	fmt.Println("Species caught:", len(t.Species))
+	fmt.Println("Pokemon owned:", len(t.Pokemon))
+	fmt.Println("Balls thrown:", t.Stats.BallsThrown)
+	fmt.Println("Catches:", t.Stats.Caught)
+	fmt.Println("Escapes:", t.Stats.Escaped)
+}
+
+func profileList(cfg *cmdConfig) error {
+	names, err := cfg.store.Profiles()
+	if err != nil {
+		return err
+	}
+
+	// the active profile only shows up on disk once it was saved
+	if !cfg.store.Exists(cfg.profile) {
+		names = append(names, cfg.profile)
+	}
+
+	fmt.Println("Profiles:")
+	for _, name := range names {
+		marker := " "
+		if name == cfg.profile {
+			marker = "*"
+		}
+		fmt.Printf(" %s %s\n", marker, name)
+	}
+	return nil
+}
+
+func profileNew(cfg *cmdConfig, name string) error {
+	err := cfg.store.Create(name, pokesave.SaveFile{
+		Trainer: *trainer.New(name),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created profile %s, use \"profile switch %s\" to play as it\n", name, name)
+	return nil
+}
+
+// saves the current profile and makes name the active one
+func profileSwitch(cfg *cmdConfig, name string) error {
+	if name == cfg.profile {
+		fmt.Println("Already using profile", name)
+		return nil
+	}
+	if !cfg.store.Exists(name) {
+		return fmt.Errorf("%w: %s", pokesave.ErrNoProfile, name)
+	}
+
+	t, err := loadTrainer(cfg, name)
+	if err != nil {
+		return err
+	}
+	if err := saveTrainer(cfg); err != nil {
+		return err
+	}
+	if err := cfg.store.SetActive(name); err != nil {
+		return err
+	}
+
+	cfg.profile = name
+	cfg.trainer = t
+	fmt.Printf("Switched to profile %s (%d pokemon owned)\n", name, len(t.Pokemon))
+	return nil
+}
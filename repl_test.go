@@ -1,9 +1,29 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/testutil"
+	"github.com/snyderg13/pokedex/internal/trainer"
 )
 
+// TestMain fails the package if any test leaves a client's cache running
+func TestMain(m *testing.M) {
+	testutil.CheckReapers(m)
+}
+
+// newTestClient creates a pokeapi client that is closed when the test
+// ends, so its default cache doesn't outlive the test
+func newTestClient(t *testing.T, opts ...pokeapi.Option) *pokeapi.Client {
+	t.Helper()
+	client := pokeapi.NewClient(opts...)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
 func TestCleanInput(t *testing.T) {
 	cases := []struct {
 		input    string
@@ -55,3 +75,63 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestFriendlyError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{
+			err:      fmt.Errorf("%w: %s", errNoSuchPokemon, "pikachuu"),
+			expected: "no such pokemon: pikachuu",
+		},
+		{
+			err:      &pokeapi.StatusError{StatusCode: 404, URL: "https://pokeapi.co/api/v2/item/foo/"},
+			expected: "PokeAPI has no data for that",
+		},
+		{
+			err:      &pokeapi.StatusError{StatusCode: 429, URL: "https://pokeapi.co/api/v2/pokemon/ditto/"},
+			expected: "PokeAPI is rate limiting us, try again in a moment",
+		},
+		{
+			err:      &pokeapi.StatusError{StatusCode: 502, URL: "https://pokeapi.co/api/v2/pokemon/ditto/"},
+			expected: "PokeAPI is having trouble right now (status 502)",
+		},
+		{
+			err:      &pokeapi.DecodeError{URL: "https://pokeapi.co/api/v2/pokemon/ditto/", Err: errors.New("bad json")},
+			expected: "PokeAPI sent a response we couldn't understand",
+		},
+		{
+			err:      errors.New("no pokemon name provided"),
+			expected: "no pokemon name provided",
+		},
+	}
+
+	for _, c := range cases {
+		actual := friendlyError(c.err)
+		if actual != c.expected {
+			t.Errorf("FAIL: %s != %s\n", actual, c.expected)
+		}
+	}
+}
+
+func TestNicknameKeepsCase(t *testing.T) {
+	initCmds()
+	cfg := &cmdConfig{trainer: trainer.New("ash")}
+	p := &trainer.Pokemon{}
+	cfg.trainer.RecordThrow(pokeapi.PokemonStats{ID: 25, Name: "pikachu"}, p)
+
+	line := "Nickname #1 Sparky McZap"
+	cmd := pokeCmds[cleanInput(line)[0]]
+	if err := runCommand(cmd, cfg, commandArgs(cmd, line)...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Nickname != "Sparky McZap" {
+		t.Errorf("expected the nickname as typed, got %q", p.Nickname)
+	}
+
+	// other commands still get lowercased arguments
+	if args := commandArgs(pokeCmds["catch"], "catch Pikachu"); len(args) != 1 || args[0] != "pikachu" {
+		t.Errorf("expected catch to get [pikachu], got %v", args)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/snyderg13/pokedex/internal/pokesave"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+// writes the current trainer to the active profile
+func saveTrainer(cfg *cmdConfig) error {
+	return cfg.store.Save(cfg.profile, pokesave.SaveFile{
+		Trainer: *cfg.trainer,
+	})
+}
+
+// loads the trainer saved in profile; a profile that was
+// never saved starts out with a fresh trainer
+func loadTrainer(cfg *cmdConfig, profile string) (*trainer.Trainer, error) {
+	save, err := cfg.store.Load(profile)
+	if errors.Is(err, pokesave.ErrNoSave) {
+		return trainer.New(profile), nil
+	} else if err != nil {
+		return nil, err
+	}
+	if save.Trainer.Name == "" {
+		save.Trainer.Name = profile
+	}
+	return &save.Trainer, nil
+}
+
+// saves after something worth keeping happened; failing to
+// save shouldn't fail the command that triggered it
+func autosave(cfg *cmdConfig) {
+	if cfg.store == nil {
+		return
+	}
+	if err := saveTrainer(cfg); err != nil {
+		fmt.Println("warning: autosave failed:", err)
+	}
+}
+
+func commandSave(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if err := saveTrainer(cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d pokemon to profile %s\n", len(cfg.trainer.Pokemon), cfg.profile)
+	return nil
+}
+
+func commandLoad(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if !cfg.store.Exists(cfg.profile) {
+		fmt.Println("There is no saved Pokedex yet")
+		return nil
+	}
+	t, err := loadTrainer(cfg, cfg.profile)
+	if err != nil {
+		return err
+	}
+	cfg.trainer = t
+	fmt.Printf("Loaded %d pokemon from profile %s\n", len(cfg.trainer.Pokemon), cfg.profile)
+	return nil
+}
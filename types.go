@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+)
+
+// returns the type chart, fetching it the first time it's needed
+func typeChart(ctx context.Context, cfg *cmdConfig) (pokeapi.TypeChart, error) {
+	if cfg.chart != nil {
+		return *cfg.chart, nil
+	}
+	chart, err := cfg.client.GetTypeChart(ctx)
+	if err != nil {
+		return pokeapi.TypeChart{}, err
+	}
+	cfg.chart = &chart
+	return chart, nil
+}
+
+// resolves a command argument to a list of types: one of the trainer's
+// pokemon (#id), a type or dual type like water/flying, or a pokemon name
+func typesOf(ctx context.Context, cfg *cmdConfig, chart pokeapi.TypeChart, arg string) ([]string, error) {
+	if parts := strings.Split(arg, "/"); len(parts) <= 2 && !slices.ContainsFunc(parts, func(t string) bool { return !chart.Has(t) }) {
+		return parts, nil
+	}
+	var pokemonTypes []pokeapi.PokemonType
+	if strings.HasPrefix(arg, "#") {
+		// the saved species data has the types, no need to fetch
+		p, err := findPokemon(cfg, arg)
+		if err != nil {
+			return nil, err
+		}
+		pokemonTypes = cfg.trainer.Species[p.Species].Types
+	} else {
+		stats, err := pokemonData(ctx, cfg, arg)
+		if err != nil {
+			return nil, err
+		}
+		pokemonTypes = stats.Types
+	}
+
+	var types []string
+	for _, t := range pokemonTypes {
+		types = append(types, t.Type.Name)
+	}
+	return types, nil
+}
+
+func commandTypes(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("not enough args, expected <pokemon_name|#id|type[/type]>")
+	}
+	chart, err := typeChart(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	types, err := typesOf(ctx, cfg, chart, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", args[0], strings.Join(types, "/"))
+
+	against := chart.Against(types...)
+	printMatchups("Weak to:", against, func(m float64) bool { return m > 1 })
+	printMatchups("Resists:", against, func(m float64) bool { return m > 0 && m < 1 })
+	printMatchups("Immune to:", against, func(m float64) bool { return m == 0 })
+	return nil
+}
+
+// prints the attacking types whose multiplier matches, strongest first
+func printMatchups(title string, against map[string]float64, match func(float64) bool) {
+	var types []string
+	for t, m := range against {
+		if match(m) {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return
+	}
+	slices.SortFunc(types, func(a, b string) int {
+		if against[a] != against[b] {
+			if against[a] > against[b] {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	fmt.Println(title)
+	for _, t := range types {
+		fmt.Printf("  - %s (%s)\n", t, formatMultiplier(against[t]))
+	}
+}
+
+func commandMatchup(ctx context.Context, cfg *cmdConfig, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("not enough args, expected <attacker> <defender>")
+	}
+	chart, err := typeChart(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	attacking, err := typesOf(ctx, cfg, chart, args[0])
+	if err != nil {
+		return err
+	}
+	defending, err := typesOf(ctx, cfg, chart, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s) vs %s (%s)\n", args[0], strings.Join(attacking, "/"), args[1], strings.Join(defending, "/"))
+	for _, t := range attacking {
+		eff := chart.Effectiveness(t, defending...)
+		fmt.Printf("  %s moves: %s, %s\n", t, formatMultiplier(eff), describeEffectiveness(eff))
+	}
+	return nil
+}
+
+func formatMultiplier(m float64) string {
+	return "x" + strconv.FormatFloat(m, 'g', -1, 64)
+}
+
+func describeEffectiveness(eff float64) string {
+	switch {
+	case eff == 0:
+		return "no effect"
+	case eff > 1:
+		return "super effective"
+	case eff < 1:
+		return "not very effective"
+	}
+	return "normal damage"
+}
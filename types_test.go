@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/snyderg13/pokedex/internal/pokeapi"
+	"github.com/snyderg13/pokedex/internal/trainer"
+)
+
+func TestTypesOf(t *testing.T) {
+	chart := pokeapi.NewTypeChart([]pokeapi.Type{
+		{Name: "water", DamageRelations: pokeapi.DamageRelations{HalfDamageTo: []pokeapi.NamedResource{{Name: "water"}}}},
+		{Name: "flying", DamageRelations: pokeapi.DamageRelations{NoDamageFrom: []pokeapi.NamedResource{{Name: "ground"}}}},
+	})
+
+	var gyarados pokeapi.PokemonStats
+	data := `{"name": "gyarados", "types": [{"slot": 1, "type": {"name": "water"}}, {"slot": 2, "type": {"name": "flying"}}]}`
+	if err := json.Unmarshal([]byte(data), &gyarados); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &cmdConfig{trainer: trainer.New("ash")}
+	cfg.trainer.RecordThrow(gyarados, &trainer.Pokemon{})
+
+	cases := []struct {
+		arg      string
+		expected []string
+	}{
+		{arg: "water", expected: []string{"water"}},
+		{arg: "water/flying", expected: []string{"water", "flying"}},
+		{arg: "#1", expected: []string{"water", "flying"}},
+	}
+	for _, c := range cases {
+		types, err := typesOf(context.Background(), cfg, chart, c.arg)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.arg, err)
+			continue
+		}
+		if !slices.Equal(types, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.arg, c.expected, types)
+		}
+	}
+
+	if _, err := typesOf(context.Background(), cfg, chart, "#2"); !errors.Is(err, trainer.ErrNoSuchPokemon) {
+		t.Errorf("expected ErrNoSuchPokemon, got %v", err)
+	}
+}
+
+func TestDescribeEffectiveness(t *testing.T) {
+	cases := []struct {
+		eff      float64
+		expected string
+	}{
+		{eff: 4, expected: "x4, super effective"},
+		{eff: 1, expected: "x1, normal damage"},
+		{eff: 0.25, expected: "x0.25, not very effective"},
+		{eff: 0, expected: "x0, no effect"},
+	}
+	for _, c := range cases {
+		if actual := formatMultiplier(c.eff) + ", " + describeEffectiveness(c.eff); actual != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, actual)
+		}
+	}
+}